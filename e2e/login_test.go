@@ -23,15 +23,26 @@ import (
 )
 
 func TestUserNeedsToBeLoggedIn(t *testing.T) {
-	cmd, cleanup := hubToolCmd(t, "--version")
+	cmd, cleanup := hubToolCmd(t, "repo", "ls")
 	// Remove the config file
 	cleanup()
 
 	output := icmd.RunCmd(cmd)
 	output.Equal(icmd.Expected{
-		ExitCode: 1,
-		Out: `You need to be logged in to Docker Hub to use this tool.
-Please login to Docker Hub using the "docker login" command
+		ExitCode: 3,
+		Err: `Error: You need to be logged in to Docker Hub to use this tool.
+Please login to Docker Hub using the "hub-tool login" command.
 `,
 	})
 }
+
+func TestErrorFormatJSON(t *testing.T) {
+	cmd, cleanup := hubToolCmd(t, "--error-format", "json", "repo", "ls")
+	cleanup()
+
+	output := icmd.RunCmd(cmd)
+	output.Assert(t, icmd.Expected{
+		ExitCode: 3,
+		Err:      `{"class":"auth_required",`,
+	})
+}
@@ -28,8 +28,10 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -47,6 +49,7 @@ func newInfoCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 		Use:                   infoName + " [OPTIONS] [ORGANIZATION]",
 		Short:                 "Print the account information",
 		Args:                  cli.RequiresMaxArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
 		DisableFlagsInUseLine: true,
 		Annotations: map[string]string{
 			"sudo": "true",
@@ -91,7 +94,7 @@ func runOrgInfo(streams command.Streams, hubClient *hub.Client, opts infoOptions
 		return checkForbiddenError(err)
 	}
 
-	return opts.Print(streams.Out(), account{org, plan, consumption}, printAccount)
+	return printAccountResource(streams, opts, account{org, plan, consumption})
 }
 
 func runUserInfo(streams command.Streams, hubClient *hub.Client, opts infoOptions) error {
@@ -108,7 +111,12 @@ func runUserInfo(streams command.Streams, hubClient *hub.Client, opts infoOption
 		return checkForbiddenError(err)
 	}
 
-	return opts.Print(streams.Out(), account{user, plan, consumption}, printAccount)
+	return printAccountResource(streams, opts, account{user, plan, consumption})
+}
+
+func printAccountResource(streams command.Streams, opts infoOptions, a account) error {
+	item := schema.NewAccount(*a.Account, *a.Plan, *a.Consumption)
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindAccount, Value: a, Item: item}, printAccount)
 }
 
 func checkForbiddenError(err error) error {
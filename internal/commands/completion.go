@@ -0,0 +1,81 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/metrics"
+)
+
+const (
+	completionName = "completion"
+)
+
+func newCompletionCmd(streams command.Streams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   completionName + " bash|zsh|fish|powershell",
+		Short: "Generate the autocompletion script for the specified shell",
+		Long: `Generate the autocompletion script for the specified shell.
+
+The namespaces, repositories, tags and token UUIDs are completed from Docker Hub
+and cached for a couple of minutes.
+
+To load the completions in your current bash shell:
+
+  source <(hub-tool completion bash)
+
+To load the completions in your current zsh shell:
+
+  source <(hub-tool completion zsh)
+
+To load the completions in your current fish shell:
+
+  hub-tool completion fish | source
+
+To load the completions in your current PowerShell session:
+
+  hub-tool completion powershell | Out-String | Invoke-Expression`,
+		Args:                  cli.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send("root", completionName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(streams.Out(), true)
+			case "zsh":
+				return root.GenZshCompletion(streams.Out())
+			case "fish":
+				return root.GenFishCompletion(streams.Out(), true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(streams.Out())
+			default:
+				return errdef.Validation(fmt.Errorf("unsupported shell %q: should be one of bash, zsh, fish, powershell", args[0]))
+			}
+		},
+	}
+	return cmd
+}
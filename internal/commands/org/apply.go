@@ -0,0 +1,106 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/orgconfig"
+	"github.com/docker/hub-tool/internal/prompt"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	applyName = "apply"
+)
+
+type applyOptions struct {
+	file  string
+	prune bool
+	force bool
+}
+
+func newApplyCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts applyOptions
+	cmd := &cobra.Command{
+		Use:   applyName + " [OPTIONS] -f FILE",
+		Short: "Reconcile an organization with its configuration file",
+		Long: `Reconcile an organization with its configuration file. The planned changes are
+printed and must be confirmed before being applied, in order. Applying stops at
+the first failure.
+
+` + orgConfigHelp,
+		Args:                  cli.NoArgs,
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, applyName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(cmd.Context(), streams, hubClient, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", `Configuration file of the organization ("-" for the standard input)`)
+	cmd.Flags().BoolVar(&opts.prune, "prune", false, "Delete the teams missing from the configuration file")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Apply the changes without confirmation")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func runApply(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts applyOptions) error {
+	if opts.file == "-" && !opts.force {
+		return errdef.Validation(errors.New("--force is required to read the configuration from the standard input, as the changes can't be confirmed"))
+	}
+	plan, err := loadPlan(streams.In(), hubClient, opts.file, opts.prune)
+	if err != nil {
+		return err
+	}
+	if err := printPlan(streams.Out(), plan); err != nil {
+		return err
+	}
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+	if !opts.force {
+		if err := confirmApply(ctx, streams, plan.Organization); err != nil {
+			return err
+		}
+	}
+	return plan.Apply(hubClient, func(change orgconfig.Change) {
+		fmt.Fprintln(streams.Out(), ansi.Emphasise("Applied:"), change.Description)
+	})
+}
+
+func confirmApply(ctx context.Context, streams command.Streams, organization string) error {
+	input, err := prompt.Ask(ctx, streams.In(), streams.Out(), fmt.Sprintf("\nPlease type the organization name %q to apply these changes: ", organization))
+	if err != nil {
+		return err
+	}
+	if input != strings.ToLower(organization) {
+		return fmt.Errorf("%q differs from the organization name, apply aborted", input)
+	}
+	return nil
+}
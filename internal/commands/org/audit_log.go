@@ -0,0 +1,333 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	auditLogName = "audit-log"
+)
+
+var (
+	auditLogColumns = []auditLogColumn{
+		{"TIME", 19, func(l hub.AuditLog) (string, int) {
+			s := l.Timestamp.Local().Format("2006-01-02 15:04:05")
+			return s, len(s)
+		}},
+		{"ACTION", 24, func(l hub.AuditLog) (string, int) { return l.Action, len(l.Action) }},
+		{"ACTOR", 16, func(l hub.AuditLog) (string, int) { return l.Actor, len(l.Actor) }},
+		{"NAME", 40, func(l hub.AuditLog) (string, int) { return l.Name, len(l.Name) }},
+	}
+	// optionalAuditLogColumns are only printed when selected with --columns
+	optionalAuditLogColumns = []auditLogColumn{
+		{"DESCRIPTION", 60, func(l hub.AuditLog) (string, int) { return l.Description, len(l.Description) }},
+	}
+)
+
+type auditLogColumn struct {
+	header string
+	// width is the fixed width of the column when following the audit log
+	width int
+	value func(l hub.AuditLog) (string, int)
+}
+
+func auditLogColumnHeader(c auditLogColumn) string {
+	return c.header
+}
+
+func auditLogColumnValue(c auditLogColumn, a hub.AuditLog) string {
+	value, _ := c.value(a)
+	return value
+}
+
+type auditLogOptions struct {
+	format.Option
+	tabwriter.Options
+	since    string
+	action   string
+	actor    string
+	all      bool
+	follow   bool
+	interval time.Duration
+}
+
+func newAuditLogCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts auditLogOptions
+	cmd := &cobra.Command{
+		Use:   auditLogName + " [OPTIONS] ORGANIZATION",
+		Short: "List the events of the audit log of an organization",
+		Long: `List the events of the audit log of an organization, the most recent first.
+
+With --follow, the new events are printed as they happen, in chronological order,
+starting from --since or from now.`,
+		Example: `  hub-tool org audit-log myorg --since 7d --action repo.delete
+  hub-tool org audit-log myorg --since 30d --actor alice --format json
+  hub-tool org audit-log myorg --follow --format ndjson`,
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, auditLogName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.follow && cmd.Flags().Changed("format") && !opts.IsStreaming() {
+				return errdef.Validation(errors.New(`--follow only supports the table and "ndjson" formats`))
+			}
+			return runAuditLog(cmd.Context(), streams, hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only list the events since a date (2006-01-02 or RFC 3339) or a duration (e.g.: 12h, 7d)")
+	cmd.Flags().StringVar(&opts.action, "action", "", `Only list the events of an action (e.g.: "repo.delete")`)
+	cmd.Flags().StringVar(&opts.actor, "actor", "", "Only list the events of a user, requires --since, --all or --follow")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch all the events")
+	cmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "Follow the new events")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 10*time.Second, "Polling interval of --follow")
+	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(auditLogColumnHeader, auditLogColumns, optionalAuditLogColumns))
+	return cmd
+}
+
+func runAuditLog(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts auditLogOptions, organization string) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, auditLogColumns, optionalAuditLogColumns, auditLogColumnHeader)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var since time.Time
+	if opts.since != "" {
+		if since, err = filter.ParseTime(opts.since, now); err != nil {
+			return errdef.Validation(err)
+		}
+	}
+	if opts.interval <= 0 {
+		return errdef.Validation(fmt.Errorf("invalid interval %s: should be a positive duration", opts.interval))
+	}
+	// The actor is filtered locally, which needs all the events of the period
+	if opts.actor != "" && since.IsZero() && !opts.all && !opts.follow {
+		return errdef.Validation(errors.New("--actor requires --since, or --all to go through the whole audit log"))
+	}
+	var reqOps []hub.RequestOp
+	if opts.action != "" {
+		reqOps = append(reqOps, hub.WithAuditLogsAction(opts.action))
+	}
+	if opts.follow {
+		if since.IsZero() {
+			since = now
+		}
+		return followAuditLogs(ctx, streams.Out(), streams.Err(), hubClient, opts, organization, columns, since, reqOps)
+	}
+	if !since.IsZero() {
+		reqOps = append(reqOps, hub.WithAuditLogsFrom(since))
+	}
+	// The actor is filtered locally, filtering only a page would be misleading
+	if opts.all || opts.actor != "" {
+		if err := hubClient.Update(hub.WithAllElements()); err != nil {
+			return err
+		}
+	}
+	if opts.IsStreaming() {
+		return streamAuditLogs(streams.Out(), hubClient, opts, organization, reqOps)
+	}
+	logs, total, err := hubClient.GetAuditLogs(organization, reqOps...)
+	if err != nil {
+		return err
+	}
+	if opts.actor != "" {
+		logs = filterActor(logs, opts.actor)
+		total = len(logs)
+	}
+	list := format.List{
+		Kind:   schema.KindAuditLog,
+		Total:  total,
+		Values: logs,
+		Items:  schema.NewAuditLogs(logs),
+	}
+	return opts.PrintList(streams.Out(), list, printAuditLogs(total, columns, &opts.Options), tabwriter.Records(columns, auditLogColumnHeader, auditLogColumnValue))
+}
+
+// streamAuditLogs prints the events page by page, as soon as they are fetched
+func streamAuditLogs(out io.Writer, hubClient *hub.Client, opts auditLogOptions, organization string, reqOps []hub.RequestOp) error {
+	err := hubClient.Update(hub.WithPageHandler(func(page interface{}) error {
+		logs := filterActor(page.([]hub.AuditLog), opts.actor)
+		return opts.PrintList(out, format.List{Kind: schema.KindAuditLog, Values: logs, Items: schema.NewAuditLogs(logs)}, nil, nil)
+	}))
+	if err != nil {
+		return err
+	}
+	_, _, err = hubClient.GetAuditLogs(organization, reqOps...)
+	return err
+}
+
+// followAuditLogs polls the audit log and prints the new events in chronological
+// order, until the context is canceled
+func followAuditLogs(ctx context.Context, out, errOut io.Writer, hubClient *hub.Client, opts auditLogOptions, organization string, columns []auditLogColumn,
+	since time.Time, reqOps []hub.RequestOp) error {
+	if err := hubClient.Update(hub.WithAllElements()); err != nil {
+		return err
+	}
+	cursor := newAuditLogCursor(since)
+	header := true
+	for {
+		logs, _, err := hubClient.GetAuditLogs(organization, append(reqOps, hub.WithAuditLogsFrom(cursor.last))...)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case hub.IsTooManyRequestsError(err):
+			fmt.Fprintln(errOut, ansi.Warn("Rate limited by Hub, retrying at the next interval"))
+		case err != nil:
+			return err
+		default:
+			logs = filterActor(cursor.next(logs), opts.actor)
+			if len(logs) > 0 {
+				if err := printFollowedAuditLogs(out, opts, columns, logs, header); err != nil {
+					return err
+				}
+				header = false
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+func printFollowedAuditLogs(out io.Writer, opts auditLogOptions, columns []auditLogColumn, logs []hub.AuditLog, header bool) error {
+	if opts.IsStreaming() {
+		return opts.PrintList(out, format.List{Kind: schema.KindAuditLog, Values: logs, Items: schema.NewAuditLogs(logs)}, nil, nil)
+	}
+	// Each poll prints a new table, the fixed widths keep its columns aligned with the previous ones
+	widths := make([]int, len(columns))
+	for i, column := range columns[:len(columns)-1] {
+		widths[i] = column.width
+	}
+	tw := opts.Options.NewFixed(out, "    ", widths)
+	if header {
+		for _, column := range columns {
+			tw.Column(ansi.Header(column.header), len(column.header))
+		}
+		tw.Line()
+	}
+	for _, log := range logs {
+		for _, column := range columns {
+			value, width := column.value(log)
+			tw.Column(value, width)
+		}
+		tw.Line()
+	}
+	return tw.Flush()
+}
+
+// auditLogCursor remembers the most recent event printed, Hub returning the
+// events of this time again when polling from it
+type auditLogCursor struct {
+	last time.Time
+	// seen are the events of the last time already printed
+	seen map[string]bool
+}
+
+func newAuditLogCursor(since time.Time) *auditLogCursor {
+	return &auditLogCursor{last: since, seen: map[string]bool{}}
+}
+
+// next returns the events not printed yet in chronological order, from the
+// events returned by Hub most recent first
+func (c *auditLogCursor) next(logs []hub.AuditLog) []hub.AuditLog {
+	var result []hub.AuditLog
+	for i := len(logs) - 1; i >= 0; i-- {
+		log := logs[i]
+		key := auditLogKey(log)
+		if log.Timestamp.Before(c.last) || (log.Timestamp.Equal(c.last) && c.seen[key]) {
+			continue
+		}
+		if log.Timestamp.After(c.last) {
+			c.last = log.Timestamp
+			c.seen = map[string]bool{}
+		}
+		c.seen[key] = true
+		result = append(result, log)
+	}
+	return result
+}
+
+func auditLogKey(log hub.AuditLog) string {
+	return strings.Join([]string{log.Timestamp.String(), log.Action, log.Name, log.Actor}, "\x00")
+}
+
+func filterActor(logs []hub.AuditLog, actor string) []hub.AuditLog {
+	if actor == "" {
+		return logs
+	}
+	var result []hub.AuditLog
+	for _, log := range logs {
+		if strings.EqualFold(log.Actor, actor) {
+			result = append(result, log)
+		}
+	}
+	return result
+}
+
+func printAuditLogs(total int, columns []auditLogColumn, table *tabwriter.Options) format.PrettyPrinter {
+	return func(out io.Writer, values interface{}) error {
+		logs := values.([]hub.AuditLog)
+		tw := table.New(out, "    ")
+		for _, column := range columns {
+			tw.Column(ansi.Header(column.header), len(column.header))
+		}
+
+		tw.Line()
+
+		for _, log := range logs {
+			for _, column := range columns {
+				value, width := column.value(log)
+				tw.Column(value, width)
+			}
+			tw.Line()
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		if len(logs) < total {
+			fmt.Fprintln(out, ansi.Info(fmt.Sprintf("%v/%v listed, use --all flag to show all", len(logs), total)))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func names(logs []hub.AuditLog) []string {
+	var result []string
+	for _, l := range logs {
+		result = append(result, l.Name)
+	}
+	return result
+}
+
+func TestAuditLogCursorOnlyReturnsNewEvents(t *testing.T) {
+	start := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	cursor := newAuditLogCursor(start)
+
+	// Hub returns the most recent events first
+	logs := cursor.next([]hub.AuditLog{
+		{Name: "c", Timestamp: start.Add(2 * time.Minute)},
+		{Name: "b", Timestamp: start.Add(time.Minute)},
+		{Name: "a", Timestamp: start},
+	})
+	assert.DeepEqual(t, names(logs), []string{"a", "b", "c"})
+
+	// Polling from the last event returns it again with the new ones
+	logs = cursor.next([]hub.AuditLog{
+		{Name: "e", Timestamp: start.Add(3 * time.Minute)},
+		{Name: "d", Timestamp: start.Add(2 * time.Minute)},
+		{Name: "c", Timestamp: start.Add(2 * time.Minute)},
+	})
+	assert.DeepEqual(t, names(logs), []string{"d", "e"})
+
+	logs = cursor.next([]hub.AuditLog{{Name: "e", Timestamp: start.Add(3 * time.Minute)}})
+	assert.Equal(t, len(logs), 0)
+}
+
+func TestFilterActor(t *testing.T) {
+	logs := []hub.AuditLog{{Name: "a", Actor: "alice"}, {Name: "b", Actor: "bob"}, {Name: "c", Actor: "Alice"}}
+	assert.DeepEqual(t, names(filterActor(logs, "alice")), []string{"a", "c"})
+	assert.Equal(t, len(filterActor(logs, "")), 3)
+}
+
+func TestAuditLogActorRequiresSince(t *testing.T) {
+	err := runAuditLog(context.Background(), nil, nil, auditLogOptions{actor: "alice", interval: time.Second}, "myorg")
+	assert.ErrorContains(t, err, "--actor requires --since")
+	assert.Equal(t, errdef.ExitCode(err), 2)
+}
+
+func TestFollowedAuditLogsStayAligned(t *testing.T) {
+	start := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	out := bytes.NewBuffer(nil)
+	err := printFollowedAuditLogs(out, auditLogOptions{}, auditLogColumns, []hub.AuditLog{
+		{Action: "repo.create", Actor: "al", Name: "myorg/a", Timestamp: start},
+	}, true)
+	assert.NilError(t, err)
+	err = printFollowedAuditLogs(out, auditLogOptions{}, auditLogColumns, []hub.AuditLog{
+		{Action: "team.member.add", Actor: "bartholomew", Name: "myorg/owners", Timestamp: start.Add(time.Minute)},
+	}, false)
+	assert.NilError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Equal(t, len(lines), 3)
+	assert.Equal(t, strings.Index(lines[1], "al "), strings.Index(lines[2], "bartholomew"))
+	assert.Equal(t, strings.Index(lines[1], "myorg/a"), strings.Index(lines[2], "myorg/owners"))
+}
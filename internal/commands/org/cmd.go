@@ -21,6 +21,8 @@ import (
 	"github.com/docker/cli/cli/command"
 	"github.com/spf13/cobra"
 
+	"github.com/docker/hub-tool/internal/commands/org/invites"
+	"github.com/docker/hub-tool/internal/commands/org/tokens"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -41,6 +43,11 @@ func NewOrgCmd(streams command.Streams, hubClient *hub.Client) *cobra.Command {
 		newListCmd(streams, hubClient, orgName),
 		newMembersCmd(streams, hubClient, orgName),
 		newTeamsCmd(streams, hubClient, orgName),
+		tokens.NewTokensCmd(streams, hubClient, orgName),
+		invites.NewInvitesCmd(streams, hubClient, orgName),
+		newPlanCmd(streams, hubClient, orgName),
+		newApplyCmd(streams, hubClient, orgName),
+		newAuditLogCmd(streams, hubClient, orgName),
 	)
 	return cmd
 }
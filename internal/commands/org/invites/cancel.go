@@ -0,0 +1,59 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package invites
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	cancelName = "cancel"
+)
+
+func newCancelCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   cancelName + " ORGANIZATION INVITE [INVITE...]",
+		Short:                 "Cancel pending invites, by invitee or invite ID",
+		Args:                  cli.RequiresMinArgs(2),
+		ValidArgsFunction:     completion.Invites(hubClient),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, cancelName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCancel(streams, hubClient, args[0], args[1:])
+		},
+	}
+	return cmd
+}
+
+func runCancel(streams command.Streams, hubClient *hub.Client, organization string, refs []string) error {
+	invites, err := selectInvites(hubClient, organization, refs)
+	if err != nil {
+		return err
+	}
+	return runInvites(streams.Out(), streams.Err(), "cancel", invites, func(invite hub.Invite) error {
+		return hubClient.CancelInvite(invite.ID)
+	}, ansi.Emphasise("Invite canceled for")+" %s")
+}
@@ -0,0 +1,46 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package invites
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	invitesName = "invites"
+)
+
+// NewInvitesCmd configures the organization pending invites manage command
+func NewInvitesCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   invitesName,
+		Short: "Manage the pending invites of an organization",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(streams.Err()),
+	}
+	parent = parent + " " + invitesName
+	cmd.AddCommand(
+		newListCmd(streams, hubClient, parent),
+		newResendCmd(streams, hubClient, parent),
+		newCancelCmd(streams, hubClient, parent),
+	)
+	return cmd
+}
@@ -0,0 +1,140 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package invites
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	lsName = "ls"
+)
+
+var (
+	defaultColumns = []column{
+		{"INVITEE", func(i hub.Invite) (string, int) { return i.Invitee, len(i.Invitee) }},
+		{"TEAM", func(i hub.Invite) (string, int) { return i.Team, len(i.Team) }},
+		{"ROLE", func(i hub.Invite) (string, int) { return i.Role, len(i.Role) }},
+		{"INVITED BY", func(i hub.Invite) (string, int) { return i.Inviter, len(i.Inviter) }},
+		{"SENT", func(i hub.Invite) (string, int) {
+			if i.CreatedAt.IsZero() {
+				return "", 0
+			}
+			s := fmt.Sprintf("%s ago", units.HumanDuration(time.Since(i.CreatedAt)))
+			return s, len(s)
+		}},
+	}
+	// optionalColumns are only printed when selected with --columns
+	optionalColumns = []column{
+		{"ID", func(i hub.Invite) (string, int) { return i.ID, len(i.ID) }},
+	}
+)
+
+type column struct {
+	header string
+	value  func(i hub.Invite) (string, int)
+}
+
+func columnHeader(c column) string {
+	return c.header
+}
+
+func columnValue(c column, i hub.Invite) string {
+	value, _ := c.value(i)
+	return value
+}
+
+type listOptions struct {
+	format.Option
+	tabwriter.Options
+}
+
+func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:                   lsName + " [OPTIONS] ORGANIZATION",
+		Aliases:               []string{"list"},
+		Short:                 "List the pending invites of an organization",
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, lsName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runList(streams, hubClient, opts, args[0])
+		},
+	}
+	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(columnHeader, defaultColumns, optionalColumns))
+	return cmd
+}
+
+func runList(streams command.Streams, hubClient *hub.Client, opts listOptions, organization string) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, defaultColumns, optionalColumns, columnHeader)
+	if err != nil {
+		return err
+	}
+	invites, err := hubClient.GetInvites(organization)
+	if err != nil {
+		return err
+	}
+	list := format.List{
+		Kind:   schema.KindInvite,
+		Total:  len(invites),
+		Values: invites,
+		Items:  schema.NewInvites(invites),
+	}
+	return opts.PrintList(streams.Out(), list, printInvites(columns, &opts.Options), tabwriter.Records(columns, columnHeader, columnValue))
+}
+
+func printInvites(columns []column, table *tabwriter.Options) format.PrettyPrinter {
+	return func(out io.Writer, values interface{}) error {
+		invites := values.([]hub.Invite)
+		tw := table.New(out, "    ")
+		for _, column := range columns {
+			tw.Column(ansi.Header(column.header), len(column.header))
+		}
+
+		tw.Line()
+
+		for _, invite := range invites {
+			for _, column := range columns {
+				value, width := column.value(invite)
+				tw.Column(value, width)
+			}
+			tw.Line()
+		}
+
+		return tw.Flush()
+	}
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package invites
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// selectInvites returns the pending invites of the organization matching the
+// references, which are either invite IDs or invitees
+func selectInvites(hubClient *hub.Client, organization string, refs []string) ([]hub.Invite, error) {
+	invites, err := hubClient.GetInvites(organization)
+	if err != nil {
+		return nil, err
+	}
+	var selected []hub.Invite
+	for _, ref := range refs {
+		found := false
+		for _, invite := range invites {
+			if invite.ID == ref || strings.EqualFold(invite.Invitee, ref) {
+				selected = append(selected, invite)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no pending invite for %s in %s: %w", ref, organization, errdef.ErrNotFound)
+		}
+	}
+	return selected, nil
+}
+
+// runInvites calls the operation on each invite, printing the outcome for each one.
+// Failing for an invite doesn't stop the operation on the others.
+func runInvites(out, errOut io.Writer, action string, invites []hub.Invite, operation func(hub.Invite) error, done string) error {
+	failed := 0
+	for _, invite := range invites {
+		if err := operation(invite); err != nil {
+			failed++
+			fmt.Fprintf(errOut, "%s: %s\n", invite.Invitee, err)
+			continue
+		}
+		fmt.Fprintf(out, done+"\n", invite.Invitee)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d invites", action, failed, len(invites))
+	}
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/format/tabwriter"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -59,8 +60,18 @@ type column struct {
 	value  func(o hub.Organization) (string, int)
 }
 
+func columnHeader(c column) string {
+	return c.header
+}
+
+func columnValue(c column, o hub.Organization) string {
+	value, _ := c.value(o)
+	return value
+}
+
 type listOptions struct {
 	format.Option
+	tabwriter.Options
 }
 
 func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
@@ -79,35 +90,48 @@ func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 		},
 	}
 	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(columnHeader, defaultColumns))
 	return cmd
 }
 
 func runList(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts listOptions) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, defaultColumns, nil, columnHeader)
+	if err != nil {
+		return err
+	}
 	organizations, err := hubClient.GetOrganizations(ctx)
 	if err != nil {
 		return err
 	}
-	return opts.Print(streams.Out(), organizations, printOrganizations)
+	list := format.List{
+		Kind:   schema.KindOrganization,
+		Total:  len(organizations),
+		Values: organizations,
+		Items:  schema.NewOrganizations(organizations),
+	}
+	return opts.PrintList(streams.Out(), list, printOrganizations(columns, &opts.Options), tabwriter.Records(columns, columnHeader, columnValue))
 }
 
-func printOrganizations(out io.Writer, values interface{}) error {
-	organizations := values.([]hub.Organization)
+func printOrganizations(columns []column, table *tabwriter.Options) format.PrettyPrinter {
+	return func(out io.Writer, values interface{}) error {
+		organizations := values.([]hub.Organization)
 
-	tw := tabwriter.New(out, "    ")
+		tw := table.New(out, "    ")
 
-	for _, c := range defaultColumns {
-		tw.Column(ansi.Header(c.header), len(c.header))
-	}
+		for _, c := range columns {
+			tw.Column(ansi.Header(c.header), len(c.header))
+		}
 
-	tw.Line()
+		tw.Line()
 
-	for _, org := range organizations {
-		for _, column := range defaultColumns {
-			value, width := column.value(org)
-			tw.Column(value, width)
+		for _, org := range organizations {
+			for _, column := range columns {
+				value, width := column.value(org)
+				tw.Column(value, width)
+			}
+			tw.Line()
 		}
-		tw.Line()
-	}
 
-	return tw.Flush()
+		return tw.Flush()
+	}
 }
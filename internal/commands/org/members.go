@@ -24,9 +24,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/format/tabwriter"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -46,16 +48,27 @@ type memberColumn struct {
 	value  func(m hub.Member) (string, int)
 }
 
+func memberColumnHeader(c memberColumn) string {
+	return c.header
+}
+
+func memberColumnValue(c memberColumn, m hub.Member) string {
+	value, _ := c.value(m)
+	return value
+}
+
 type memberOptions struct {
 	format.Option
+	tabwriter.Options
 }
 
 func newMembersCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
 	var opts memberOptions
 	cmd := &cobra.Command{
 		Use:                   membersName + " ORGANIZATION",
-		Short:                 "List all the members in an organization",
+		Short:                 "List all the members in an organization, or invite and remove members",
 		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, membersName)
@@ -65,33 +78,50 @@ func newMembersCmd(streams command.Streams, hubClient *hub.Client, parent string
 		},
 	}
 	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(memberColumnHeader, memberColumns))
+	cmd.AddCommand(
+		newInviteCmd(streams, hubClient, parent+" "+membersName),
+		newMemberRmCmd(streams, hubClient, parent+" "+membersName),
+	)
 	return cmd
 }
 
 func runMembers(streams command.Streams, hubClient *hub.Client, opts memberOptions, organization string) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, memberColumns, nil, memberColumnHeader)
+	if err != nil {
+		return err
+	}
 	members, err := hubClient.GetMembers(organization)
 	if err != nil {
 		return err
 	}
-	return opts.Print(streams.Out(), members, printMembers)
+	list := format.List{
+		Kind:   schema.KindMember,
+		Total:  len(members),
+		Values: members,
+		Items:  schema.NewMembers(members),
+	}
+	return opts.PrintList(streams.Out(), list, printMembers(columns, &opts.Options), tabwriter.Records(columns, memberColumnHeader, memberColumnValue))
 }
 
-func printMembers(out io.Writer, values interface{}) error {
-	members := values.([]hub.Member)
-	tw := tabwriter.New(out, "    ")
-	for _, column := range memberColumns {
-		tw.Column(ansi.Header(column.header), len(column.header))
-	}
+func printMembers(columns []memberColumn, table *tabwriter.Options) format.PrettyPrinter {
+	return func(out io.Writer, values interface{}) error {
+		members := values.([]hub.Member)
+		tw := table.New(out, "    ")
+		for _, column := range columns {
+			tw.Column(ansi.Header(column.header), len(column.header))
+		}
 
-	tw.Line()
+		tw.Line()
 
-	for _, member := range members {
-		for _, column := range memberColumns {
-			value, width := column.value(member)
-			tw.Column(value, width)
+		for _, member := range members {
+			for _, column := range columns {
+				value, width := column.value(member)
+				tw.Column(value, width)
+			}
+			tw.Line()
 		}
-		tw.Line()
-	}
 
-	return tw.Flush()
+		return tw.Flush()
+	}
 }
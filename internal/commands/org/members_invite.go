@@ -0,0 +1,201 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	inviteName = "invite"
+)
+
+type inviteOptions struct {
+	emails    []string
+	usernames []string
+	team      string
+	role      string
+	fromCSV   string
+}
+
+// inviteRow is a user to invite, line is the line of the CSV file it was read from
+type inviteRow struct {
+	line    int
+	invitee string
+	team    string
+	role    string
+}
+
+func (r inviteRow) String() string {
+	if r.line > 0 {
+		return fmt.Sprintf("line %d: %s", r.line, r.invitee)
+	}
+	return r.invitee
+}
+
+func newInviteCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts inviteOptions
+	cmd := &cobra.Command{
+		Use:   inviteName + " [OPTIONS] ORGANIZATION",
+		Short: "Invite users to join an organization",
+		Long: `Invite users to join an organization, by email or Docker ID.
+
+Many users can be invited at once from a CSV file with --from-csv. Each line
+of the file is "INVITEE[,TEAM[,ROLE]]", the team and role defaulting to --team
+and --role. The outcome is reported for each line, failing for one doesn't stop
+inviting the others.`,
+		Example: `  hub-tool org members invite myorg --email alice@example.com --team dev
+  hub-tool org members invite myorg --from-csv engineers.csv`,
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, inviteName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runInvite(streams, hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().StringArrayVar(&opts.emails, "email", nil, "Email of a user to invite")
+	cmd.Flags().StringArrayVar(&opts.usernames, "username", nil, "Docker ID of a user to invite")
+	cmd.Flags().StringVar(&opts.team, "team", "", "Team the users will join")
+	cmd.Flags().StringVar(&opts.role, "role", hub.RoleMember, fmt.Sprintf("Role of the users in the organization, one of %s", strings.Join(hub.Roles, ", ")))
+	cmd.Flags().StringVar(&opts.fromCSV, "from-csv", "", `Invite the users listed in a CSV file ("-" for the standard input)`)
+	_ = cmd.RegisterFlagCompletionFunc("role", cobra.FixedCompletions(hub.Roles, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func runInvite(streams command.Streams, hubClient *hub.Client, opts inviteOptions, organization string) error {
+	var rows []inviteRow
+	for _, invitees := range [][]string{opts.emails, opts.usernames} {
+		for _, invitee := range invitees {
+			rows = append(rows, inviteRow{invitee: invitee, team: opts.team, role: opts.role})
+		}
+	}
+	if opts.fromCSV != "" {
+		var in io.Reader = streams.In()
+		if opts.fromCSV != "-" {
+			f, err := os.Open(opts.fromCSV)
+			if err != nil {
+				return err
+			}
+			defer f.Close() //nolint:errcheck
+			in = f
+		}
+		csvRows, err := readInviteRows(in, opts.team, opts.role)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, csvRows...)
+	}
+	if len(rows) == 0 {
+		return errdef.Validation(errors.New("no user to invite: use --email, --username or --from-csv"))
+	}
+	for _, row := range rows {
+		if !slices.Contains(hub.Roles, row.role) {
+			return errdef.Validation(fmt.Errorf("%s: invalid role %q, should be one of %s", row, row.role, strings.Join(hub.Roles, ", ")))
+		}
+	}
+	return inviteRows(streams.Out(), streams.Err(), hubClient, organization, rows)
+}
+
+// readInviteRows reads the users to invite from a CSV file, skipping the empty lines
+// and a header starting with "invitee"
+func readInviteRows(in io.Reader, team, role string) ([]inviteRow, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	var rows []inviteRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, errdef.Validation(err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "invitee") {
+			continue
+		}
+		if len(record) > 3 {
+			return nil, errdef.Validation(fmt.Errorf("line %d: expected INVITEE[,TEAM[,ROLE]], got %d fields", line, len(record)))
+		}
+		row := inviteRow{line: line, invitee: strings.TrimSpace(record[0]), team: team, role: role}
+		if row.invitee == "" {
+			return nil, errdef.Validation(fmt.Errorf("line %d: missing invitee", line))
+		}
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			row.team = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			row.role = strings.TrimSpace(record[2])
+		}
+		rows = append(rows, row)
+	}
+}
+
+// inviteRows invites the users one by one, reporting the outcome of each row.
+// Failing for a row doesn't stop inviting the others.
+func inviteRows(out, errOut io.Writer, hubClient *hub.Client, organization string, rows []inviteRow) error {
+	failed := 0
+	for _, row := range rows {
+		results, err := hubClient.InviteMembers(organization, row.team, row.role, []string{row.invitee})
+		if err == nil && len(results) != 1 {
+			err = fmt.Errorf("unexpected response with %d invitees", len(results))
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(errOut, "%s: %s\n", row, err)
+			continue
+		}
+		switch results[0].Status {
+		case hub.InviteStatusInvited:
+			if row.team != "" {
+				fmt.Fprintf(out, "%s %s to team %s\n", ansi.Emphasise("Invited"), row, row.team)
+			} else {
+				fmt.Fprintf(out, "%s %s\n", ansi.Emphasise("Invited"), row)
+			}
+		case hub.InviteStatusExistingMember:
+			fmt.Fprintln(out, ansi.Info(fmt.Sprintf("Skipped %s, already a member of %s", row, organization)))
+		default:
+			failed++
+			fmt.Fprintf(errOut, "%s: %s\n", row, strings.ReplaceAll(results[0].Status, "_", " "))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to invite %d of %d users", failed, len(rows))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func TestReadInviteRows(t *testing.T) {
+	rows, err := readInviteRows(strings.NewReader(`invitee,team,role
+alice@example.com
+# contractors
+bob, qa
+carol,,owner
+`), "dev", hub.RoleMember)
+	assert.NilError(t, err)
+	expected := []inviteRow{
+		{line: 2, invitee: "alice@example.com", team: "dev", role: hub.RoleMember},
+		{line: 4, invitee: "bob", team: "qa", role: hub.RoleMember},
+		{line: 5, invitee: "carol", team: "dev", role: hub.RoleOwner},
+	}
+	assert.Equal(t, len(rows), len(expected))
+	for i := range expected {
+		assert.Equal(t, rows[i], expected[i])
+	}
+
+	_, err = readInviteRows(strings.NewReader("alice,dev,member,extra\n"), "", "")
+	assert.Error(t, err, "line 1: expected INVITEE[,TEAM[,ROLE]], got 4 fields")
+	assert.Assert(t, errdef.IsValidation(err))
+	_, err = readInviteRows(strings.NewReader("alice\n,dev\n"), "", "")
+	assert.Error(t, err, "line 2: missing invitee")
+}
+
+func TestInviteRowsReportsEachRow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Team     string   `json:"team"`
+			Invitees []string `json:"invitees"`
+		}
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&request))
+		switch request.Invitees[0] {
+		case "alice@example.com":
+			fmt.Fprint(w, `{"invitees": [{"invitee": "alice@example.com", "status": "invited"}]}`)
+		case "bob":
+			fmt.Fprint(w, `{"invitees": [{"invitee": "bob", "status": "existing_org_member"}]}`)
+		case "carol":
+			fmt.Fprint(w, `{"invitees": [{"invitee": "carol", "status": "invalid_email_or_docker_id"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("DOCKER_HUB_API_URL", server.URL)
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+	hubClient, err := hub.NewClient()
+	assert.NilError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	errOut := bytes.NewBuffer(nil)
+	err = inviteRows(out, errOut, hubClient, "myorg", []inviteRow{
+		{line: 1, invitee: "alice@example.com", team: "dev", role: hub.RoleMember},
+		{line: 2, invitee: "bob", team: "dev", role: hub.RoleMember},
+		{line: 3, invitee: "carol", team: "dev", role: hub.RoleMember},
+		{line: 4, invitee: "dave", team: "unknown", role: hub.RoleMember},
+	})
+	assert.Error(t, err, "failed to invite 2 of 4 users")
+	assert.Equal(t, out.String(), "Invited line 1: alice@example.com to team dev\nSkipped line 2: bob, already a member of myorg\n")
+	assert.Equal(t, errOut.String(), "line 3: carol: invalid email or docker id\nline 4: dave: resource not found\n")
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	memberRmName = "rm"
+)
+
+type memberRmOptions struct {
+	force bool
+}
+
+func newMemberRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts memberRmOptions
+	cmd := &cobra.Command{
+		Use:                   memberRmName + " [OPTIONS] ORGANIZATION USERNAME",
+		Short:                 "Remove a member from an organization",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.Members(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, memberRmName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runMemberRm(streams, hubClient, opts, args[0], args[1])
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
+	return cmd
+}
+
+func runMemberRm(streams command.Streams, hubClient *hub.Client, opts memberRmOptions, organization, username string) error {
+	if !opts.force {
+		fmt.Fprintf(streams.Out(), ansi.Warn("WARNING: %s will lose access to all the repositories of %s.")+`
+The member will have to be invited again to join the organization.
+
+Please type the username %q to confirm the removal: `, username, organization, username)
+		reader := bufio.NewReader(streams.In())
+		input, _ := reader.ReadString('\n')
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input != strings.ToLower(username) {
+			return fmt.Errorf("%q differs from the username, removal aborted", input)
+		}
+	}
+
+	if err := hubClient.RemoveMember(organization, username); err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out(), ansi.Emphasise("Member removed"), username)
+	return nil
+}
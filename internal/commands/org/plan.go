@@ -0,0 +1,143 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/orgconfig"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	planName = "plan"
+
+	orgConfigHelp = `The organization is described in a YAML file. The values left out are not
+managed, an empty list of members removes all the members of a team:
+
+  organization: myorg
+  teams:
+    - name: developers
+      description: Build and push the images
+      members: [alice, bob]
+  repositories:
+    - name: app
+      description: The application
+      private: true
+      permissions:
+        developers: write
+
+The members are Docker IDs, the users who are not members of the organization
+are invited to their team.
+The repositories must already exist, their team permissions are replaced by
+the listed ones. The teams missing from the file are only deleted with --prune.`
+)
+
+type planOptions struct {
+	format.Option
+	file  string
+	prune bool
+}
+
+func newPlanCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts planOptions
+	cmd := &cobra.Command{
+		Use:                   planName + " [OPTIONS] -f FILE",
+		Short:                 "Show the changes reconciling an organization with its configuration file",
+		Long:                  "Show the changes reconciling an organization with its configuration file.\n\n" + orgConfigHelp,
+		Args:                  cli.NoArgs,
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, planName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runPlan(streams, hubClient, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", `Configuration file of the organization ("-" for the standard input)`)
+	cmd.Flags().BoolVar(&opts.prune, "prune", false, "Delete the teams missing from the configuration file")
+	opts.AddFormatFlag(cmd.Flags())
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func runPlan(streams command.Streams, hubClient *hub.Client, opts planOptions) error {
+	plan, err := loadPlan(streams.In(), hubClient, opts.file, opts.prune)
+	if err != nil {
+		return err
+	}
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindPlan, Value: plan, Item: planItem(plan)}, printPlan)
+}
+
+// planItem converts a plan to its JSON representation
+func planItem(plan *orgconfig.Plan) schema.Plan {
+	changes := make([]schema.PlanChange, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		changes = append(changes, schema.PlanChange{Action: c.Action, Resource: c.Resource, Description: c.Description})
+	}
+	return schema.Plan{Organization: plan.Organization, Changes: changes}
+}
+
+// loadPlan compares the configuration file to the live state of the organization
+func loadPlan(in io.Reader, hubClient *hub.Client, file string, prune bool) (*orgconfig.Plan, error) {
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close() //nolint:errcheck
+		in = f
+	}
+	config, err := orgconfig.Load(in)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	state, err := orgconfig.Fetch(hubClient, config)
+	if err != nil {
+		return nil, err
+	}
+	return orgconfig.NewPlan(config, state, prune)
+}
+
+func printPlan(out io.Writer, value interface{}) error {
+	plan := value.(*orgconfig.Plan)
+	if len(plan.Changes) == 0 {
+		fmt.Fprintln(out, ansi.Info(fmt.Sprintf("Organization %s is up to date", plan.Organization)))
+		return nil
+	}
+	symbols := map[string]string{
+		orgconfig.ActionAdd:    "+",
+		orgconfig.ActionChange: "~",
+		orgconfig.ActionRemove: "-",
+	}
+	for _, change := range plan.Changes {
+		fmt.Fprintf(out, "  %s %s\n", symbols[change.Action], change.Description)
+	}
+	fmt.Fprintf(out, "\n%s %d to add, %d to change, %d to remove\n", ansi.Header(fmt.Sprintf("Plan for %s:", plan.Organization)),
+		plan.Count(orgconfig.ActionAdd), plan.Count(orgconfig.ActionChange), plan.Count(orgconfig.ActionRemove))
+	return nil
+}
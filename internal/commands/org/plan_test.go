@@ -0,0 +1,39 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/orgconfig"
+)
+
+func TestPlanItem(t *testing.T) {
+	data, err := json.Marshal(planItem(&orgconfig.Plan{Organization: "myorg"}))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"organization":"myorg","changes":[]}`)
+
+	data, err = json.Marshal(planItem(&orgconfig.Plan{
+		Organization: "myorg",
+		Changes:      []orgconfig.Change{{Action: orgconfig.ActionAdd, Resource: "team dev", Description: "create team dev"}},
+	}))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"organization":"myorg","changes":[{"action":"add","resource":"team dev","description":"create team dev"}]}`)
+}
@@ -25,9 +25,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/format/tabwriter"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -51,16 +53,27 @@ type teamColumn struct {
 	value  func(t hub.Team) (string, int)
 }
 
+func teamColumnHeader(c teamColumn) string {
+	return c.header
+}
+
+func teamColumnValue(c teamColumn, t hub.Team) string {
+	value, _ := c.value(t)
+	return value
+}
+
 type teamsOptions struct {
 	format.Option
+	tabwriter.Options
 }
 
 func newTeamsCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
 	var opts teamsOptions
 	cmd := &cobra.Command{
 		Use:                   teamsName + " ORGANIZATION",
-		Short:                 "List all the teams in an organization",
+		Short:                 "List all the teams in an organization, or manage the teams and their members",
 		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, teamsName)
@@ -70,33 +83,54 @@ func newTeamsCmd(streams command.Streams, hubClient *hub.Client, parent string)
 		},
 	}
 	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(teamColumnHeader, teamsColumns))
+	teamsParent := parent + " " + teamsName
+	cmd.AddCommand(
+		newTeamCreateCmd(streams, hubClient, teamsParent),
+		newTeamUpdateCmd(streams, hubClient, teamsParent),
+		newTeamRmCmd(streams, hubClient, teamsParent),
+		newAddMemberCmd(streams, hubClient, teamsParent),
+		newRmMemberCmd(streams, hubClient, teamsParent),
+	)
 	return cmd
 }
 
 func runTeams(streams command.Streams, hubClient *hub.Client, opts teamsOptions, organization string) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, teamsColumns, nil, teamColumnHeader)
+	if err != nil {
+		return err
+	}
 	teams, err := hubClient.GetTeams(organization)
 	if err != nil {
 		return err
 	}
-	return opts.Print(streams.Out(), teams, printTeams)
+	list := format.List{
+		Kind:   schema.KindTeam,
+		Total:  len(teams),
+		Values: teams,
+		Items:  schema.NewTeams(teams),
+	}
+	return opts.PrintList(streams.Out(), list, printTeams(columns, &opts.Options), tabwriter.Records(columns, teamColumnHeader, teamColumnValue))
 }
 
-func printTeams(out io.Writer, values interface{}) error {
-	teams := values.([]hub.Team)
-	tw := tabwriter.New(out, "    ")
-
-	for _, column := range teamsColumns {
-		tw.Column(ansi.Header(column.header), len(column.header))
-	}
+func printTeams(columns []teamColumn, table *tabwriter.Options) format.PrettyPrinter {
+	return func(out io.Writer, values interface{}) error {
+		teams := values.([]hub.Team)
+		tw := table.New(out, "    ")
 
-	tw.Line()
-	for _, team := range teams {
-		for _, column := range teamsColumns {
-			value, width := column.value(team)
-			tw.Column(value, width)
+		for _, column := range columns {
+			tw.Column(ansi.Header(column.header), len(column.header))
 		}
+
 		tw.Line()
-	}
+		for _, team := range teams {
+			for _, column := range columns {
+				value, width := column.value(team)
+				tw.Column(value, width)
+			}
+			tw.Line()
+		}
 
-	return tw.Flush()
+		return tw.Flush()
+	}
 }
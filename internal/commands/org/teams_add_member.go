@@ -0,0 +1,73 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	addMemberName = "add-member"
+)
+
+func newAddMemberCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   addMemberName + " ORGANIZATION TEAM USERNAME [USERNAME...]",
+		Short:                 "Add members of an organization to a team",
+		Args:                  cli.RequiresMinArgs(3),
+		ValidArgsFunction:     completion.TeamMembers(hubClient),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, addMemberName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			organization, team := args[0], args[1]
+			return runTeamMembers(streams.Out(), streams.Err(), "add", args[2:], func(username string) error {
+				return hubClient.AddTeamMember(organization, team, username)
+			}, ansi.Emphasise("Added")+" %s to team "+team)
+		},
+	}
+	return cmd
+}
+
+// runTeamMembers calls the operation for each username, printing the outcome for each one.
+// Failing for a member doesn't stop the operation on the others.
+func runTeamMembers(out, errOut io.Writer, action string, usernames []string, operation func(string) error, done string) error {
+	failed := 0
+	for _, username := range usernames {
+		if err := operation(username); err != nil {
+			failed++
+			fmt.Fprintf(errOut, "%s: %s\n", username, err)
+			continue
+		}
+		fmt.Fprintf(out, done+"\n", username)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d members", action, failed, len(usernames))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRunTeamMembersContinuesAfterAFailure(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	errOut := bytes.NewBuffer(nil)
+	err := runTeamMembers(out, errOut, "add", []string{"alice", "bob", "carol"}, func(username string) error {
+		if username == "bob" {
+			return errors.New("not a member of the organization")
+		}
+		return nil
+	}, "Added %s to team dev")
+	assert.Error(t, err, "failed to add 1 of 3 members")
+	assert.Equal(t, out.String(), "Added alice to team dev\nAdded carol to team dev\n")
+	assert.Equal(t, errOut.String(), "bob: not a member of the organization\n")
+}
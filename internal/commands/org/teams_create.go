@@ -0,0 +1,66 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"fmt"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	teamCreateName = "create"
+)
+
+type teamCreateOptions struct {
+	description string
+}
+
+func newTeamCreateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts teamCreateOptions
+	cmd := &cobra.Command{
+		Use:                   teamCreateName + " [OPTIONS] ORGANIZATION TEAM",
+		Short:                 "Create a team in an organization",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, teamCreateName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runTeamCreate(streams, hubClient, opts, args[0], args[1])
+		},
+	}
+	cmd.Flags().StringVar(&opts.description, "description", "", "Set team's description")
+	return cmd
+}
+
+func runTeamCreate(streams command.Streams, hubClient *hub.Client, opts teamCreateOptions, organization, name string) error {
+	team, err := hubClient.CreateTeam(organization, name, opts.description)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out(), ansi.Emphasise("Team created"), team.Name)
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	teamRmName = "rm"
+)
+
+type teamRmOptions struct {
+	force bool
+}
+
+func newTeamRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts teamRmOptions
+	cmd := &cobra.Command{
+		Use:                   teamRmName + " [OPTIONS] ORGANIZATION TEAM",
+		Short:                 "Delete a team of an organization",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.Teams(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, teamRmName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runTeamRm(streams, hubClient, opts, args[0], args[1])
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
+	return cmd
+}
+
+func runTeamRm(streams command.Streams, hubClient *hub.Client, opts teamRmOptions, organization, team string) error {
+	if !opts.force {
+		fmt.Fprintf(streams.Out(), ansi.Warn("WARNING: This action is irreversible.")+`
+The members of the team will lose the permissions given by the team, but will stay in the organization.
+
+Please type the team name %q to confirm the deletion: `, team)
+		reader := bufio.NewReader(streams.In())
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input != team {
+			return fmt.Errorf("%q differs from the team name, deletion aborted", input)
+		}
+	}
+
+	if err := hubClient.RemoveTeam(organization, team); err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out(), ansi.Emphasise("Team deleted"), team)
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	rmMemberName = "rm-member"
+)
+
+func newRmMemberCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   rmMemberName + " ORGANIZATION TEAM USERNAME [USERNAME...]",
+		Short:                 "Remove members from a team, they stay in the organization",
+		Args:                  cli.RequiresMinArgs(3),
+		ValidArgsFunction:     completion.TeamMembers(hubClient),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, rmMemberName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			organization, team := args[0], args[1]
+			return runTeamMembers(streams.Out(), streams.Err(), "remove", args[2:], func(username string) error {
+				return hubClient.RemoveTeamMember(organization, team, username)
+			}, ansi.Emphasise("Removed")+" %s from team "+team)
+		},
+	}
+	return cmd
+}
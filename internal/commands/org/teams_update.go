@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package org
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	teamUpdateName = "update"
+)
+
+type teamUpdateOptions struct {
+	name        string
+	description string
+}
+
+func newTeamUpdateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts teamUpdateOptions
+	cmd := &cobra.Command{
+		Use:                   teamUpdateName + " [OPTIONS] ORGANIZATION TEAM",
+		Short:                 "Rename a team or change its description",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.Teams(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, teamUpdateName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var name, description *string
+			if cmd.Flags().Changed("name") {
+				name = &opts.name
+			}
+			if cmd.Flags().Changed("description") {
+				description = &opts.description
+			}
+			return runTeamUpdate(streams, hubClient, args[0], args[1], name, description)
+		},
+	}
+	cmd.Flags().StringVar(&opts.name, "name", "", "Rename the team")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Set team's description")
+	return cmd
+}
+
+func runTeamUpdate(streams command.Streams, hubClient *hub.Client, organization, team string, name, description *string) error {
+	if name == nil && description == nil {
+		return errdef.Validation(errors.New("nothing to update: use --name or --description"))
+	}
+	if name != nil && *name == "" {
+		return errdef.Validation(errors.New("the team name can't be empty"))
+	}
+	updated, err := hubClient.UpdateTeam(organization, team, name, description)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out(), ansi.Emphasise("Team updated"), updated.Name)
+	return nil
+}
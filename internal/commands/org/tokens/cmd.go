@@ -0,0 +1,52 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tokens
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	tokensName = "tokens"
+)
+
+// NewTokensCmd configures the organization access tokens manage command
+func NewTokensCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   tokensName,
+		Short: "Manage the access tokens of an organization",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(streams.Err()),
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+	}
+	parent = parent + " " + tokensName
+	cmd.AddCommand(
+		newCreateCmd(streams, hubClient, parent),
+		newInspectCmd(streams, hubClient, parent),
+		newListCmd(streams, hubClient, parent),
+		newActivateCmd(streams, hubClient, parent),
+		newDeactivateCmd(streams, hubClient, parent),
+		newRmCmd(streams, hubClient, parent),
+	)
+	return cmd
+}
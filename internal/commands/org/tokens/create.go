@@ -0,0 +1,179 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	createName = "create"
+
+	permissionPull = "pull"
+	permissionPush = "push"
+)
+
+type createOptions struct {
+	format.Option
+	label        string
+	description  string
+	repositories []string
+	expires      string
+	quiet        bool
+}
+
+func newCreateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts createOptions
+	cmd := &cobra.Command{
+		Use:   createName + " [OPTIONS] ORGANIZATION",
+		Short: "Create an access token for an organization",
+		Long: `Create an access token for an organization, not tied to the account of a member.
+
+The token can only access the repositories given with --repository, pulling them
+by default, or pulling and pushing them with the push permission:
+
+  hub-tool org tokens create --label ci --repository myorg/app:push --repository myorg/base myorg`,
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, createName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCreate(streams, hubClient, opts, args[0])
+		},
+	}
+	opts.AddFormatFlag(cmd.Flags())
+	cmd.Flags().StringVar(&opts.label, "label", "", "Set token's label (required)")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Set token's description")
+	cmd.Flags().StringArrayVar(&opts.repositories, "repository", nil, `Give access to a repository of the organization, as "NAMESPACE/REPOSITORY[:pull|push]" (at least one required)`)
+	cmd.Flags().StringVar(&opts.expires, "expires", "", "Expire the token after a duration, like 12h, 30d or 1y (default never)")
+	cmd.Flags().BoolVar(&opts.quiet, "quiet", false, "Display only created token")
+	_ = cmd.MarkFlagRequired("label")
+	return cmd
+}
+
+func runCreate(streams command.Streams, hubClient *hub.Client, opts createOptions, organization string) error {
+	resources, err := parseRepositories(organization, opts.repositories)
+	if err != nil {
+		return err
+	}
+	expiresAt, err := filter.ParseExpiration(opts.expires, time.Now())
+	if err != nil {
+		return errdef.Validation(err)
+	}
+	token, err := hubClient.CreateOrgToken(organization, opts.label, opts.description, resources, expiresAt)
+	if err != nil {
+		return err
+	}
+	if opts.quiet {
+		fmt.Fprintln(streams.Out(), token.Token)
+		return nil
+	}
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindOrgToken, Value: token, Item: schema.NewOrgToken(*token)}, printCreatedToken(organization))
+}
+
+// parseRepositories converts the NAMESPACE/REPOSITORY[:pull|push] permissions to the
+// resources of the token, the push permission also allowing to pull. The token can only
+// access the repositories of the organization.
+func parseRepositories(organization string, repositories []string) ([]hub.OrgTokenResource, error) {
+	if len(repositories) == 0 {
+		return nil, errdef.Validation(errors.New("at least one --repository is required"))
+	}
+	var resources []hub.OrgTokenResource
+	for _, repository := range repositories {
+		path, permission, _ := strings.Cut(repository, ":")
+		namespace, _, ok := strings.Cut(path, "/")
+		if !ok {
+			return nil, errdef.Validation(fmt.Errorf("invalid repository %q: should be NAMESPACE/REPOSITORY", path))
+		}
+		if !strings.EqualFold(namespace, organization) {
+			return nil, errdef.Validation(fmt.Errorf("invalid repository %q: should be a repository of the organization %s", path, organization))
+		}
+		scopes := []string{hub.ScopeRepoPull}
+		switch permission {
+		case "", permissionPull:
+		case permissionPush:
+			scopes = append(scopes, hub.ScopeRepoPush)
+		default:
+			return nil, errdef.Validation(fmt.Errorf("invalid permission %q for repository %q: should be %s or %s", permission, path, permissionPull, permissionPush))
+		}
+		resources = append(resources, hub.OrgTokenResource{
+			Type:   hub.ResourceTypeRepository,
+			Path:   path,
+			Scopes: scopes,
+		})
+	}
+	return resources, nil
+}
+
+// getPermission returns the permission given by the scopes of a repository resource
+func getPermission(scopes []string) string {
+	for _, scope := range scopes {
+		if scope == hub.ScopeRepoPush {
+			return permissionPush
+		}
+	}
+	return permissionPull
+}
+
+func printCreatedToken(organization string) format.PrettyPrinter {
+	return func(out io.Writer, value interface{}) error {
+		token := value.(*hub.OrgToken)
+		fmt.Fprintf(out, ansi.Emphasise("Organization Access Token successfully created!")+`
+
+`+ansi.Header("Label:")+` %s
+`+ansi.Header("Repositories:")+` %s
+`+ansi.Header("Expires:")+` %s
+
+To use the access token from your Docker CLI client:
+1. Run: docker login --username %s
+2. At the password prompt, enter the access token.
+
+    %s
+
+`+ansi.Warn(`WARNING: This access token cannot be displayed again.
+It will not be stored and cannot be retrieved. Please be sure to save it now.
+`),
+			token.Label,
+			getRepositories(token.Resources),
+			getExpiresAt(token.ExpiresAt),
+			organization,
+			ansi.Emphasise(token.Token))
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tokens
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func TestParseRepositories(t *testing.T) {
+	resources, err := parseRepositories("myorg", []string{"myorg/app:push", "myorg/base", "myorg/docs:pull"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, resources, []hub.OrgTokenResource{
+		{Type: hub.ResourceTypeRepository, Path: "myorg/app", Scopes: []string{hub.ScopeRepoPull, hub.ScopeRepoPush}},
+		{Type: hub.ResourceTypeRepository, Path: "myorg/base", Scopes: []string{hub.ScopeRepoPull}},
+		{Type: hub.ResourceTypeRepository, Path: "myorg/docs", Scopes: []string{hub.ScopeRepoPull}},
+	})
+	assert.Equal(t, getRepositories(resources), "myorg/app (push), myorg/base (pull), myorg/docs (pull)")
+
+	_, err = parseRepositories("myorg", []string{"app"})
+	assert.Error(t, err, `invalid repository "app": should be NAMESPACE/REPOSITORY`)
+	_, err = parseRepositories("myorg", []string{"myorg/app:delete"})
+	assert.Error(t, err, `invalid permission "delete" for repository "myorg/app": should be pull or push`)
+	_, err = parseRepositories("myorg", []string{"myorg/app", "otherorg/app"})
+	assert.Error(t, err, `invalid repository "otherorg/app": should be a repository of the organization myorg`)
+	assert.Assert(t, errdef.IsValidation(err))
+	_, err = parseRepositories("myorg", nil)
+	assert.Error(t, err, "at least one --repository is required")
+	assert.Assert(t, errdef.IsValidation(err))
+}
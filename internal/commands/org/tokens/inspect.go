@@ -0,0 +1,103 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tokens
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	inspectName = "inspect"
+)
+
+type inspectOptions struct {
+	format.Option
+}
+
+func newInspectCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts inspectOptions
+	cmd := &cobra.Command{
+		Use:                   inspectName + " [OPTIONS] ORGANIZATION TOKEN_ID",
+		Short:                 "Inspect an access token of an organization",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.OrgTokens(hubClient)),
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, inspectName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(streams, hubClient, opts, args[0], args[1])
+		},
+	}
+	opts.AddFormatFlag(cmd.Flags())
+	return cmd
+}
+
+func runInspect(streams command.Streams, hubClient *hub.Client, opts inspectOptions, organization, tokenID string) error {
+	id, err := uuid.Parse(tokenID)
+	if err != nil {
+		return errdef.Validation(err)
+	}
+	token, err := hubClient.GetOrgToken(organization, id.String())
+	if err != nil {
+		return err
+	}
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindOrgToken, Value: token, Item: schema.NewOrgToken(*token)}, printInspectToken)
+}
+
+func printInspectToken(out io.Writer, value interface{}) error {
+	token := value.(*hub.OrgToken)
+
+	fmt.Fprintf(out, ansi.Title("Token:")+"\n")
+	fmt.Fprintf(out, ansi.Key("ID:")+"\t%s\n", token.ID)
+	fmt.Fprintf(out, ansi.Key("Label:")+"\t%s\n", token.Label)
+	if token.Description != "" {
+		fmt.Fprintf(out, ansi.Key("Description:")+"\t%s\n", token.Description)
+	}
+	fmt.Fprintf(out, ansi.Key("Is Active:")+"\t%v\n", token.IsActive)
+	fmt.Fprintf(out, ansi.Key("Created:")+"\t%s\n", fmt.Sprintf("%s ago", units.HumanDuration(time.Since(token.CreatedAt))))
+	fmt.Fprintf(out, ansi.Key("Created By:")+"\t%s\n", token.CreatedBy)
+	fmt.Fprintf(out, ansi.Key("Last Used:")+"\t%s\n", getLastUsed(token.LastUsed))
+	fmt.Fprintf(out, ansi.Key("Expires:")+"\t%s\n", getExpiresAt(token.ExpiresAt))
+	fmt.Fprintf(out, ansi.Key("Repositories:")+"\n")
+	for _, resource := range token.Resources {
+		if resource.Type != hub.ResourceTypeRepository {
+			continue
+		}
+		fmt.Fprintf(out, "  %s\t%s\n", resource.Path, getPermission(resource.Scopes))
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tokens
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	lsName = "ls"
+)
+
+var (
+	defaultColumns = []column{
+		{"LABEL", func(t hub.OrgToken) (string, int) { return t.Label, len(t.Label) }},
+		{"ID", func(t hub.OrgToken) (string, int) { return t.ID.String(), len(t.ID.String()) }},
+		{"REPOSITORIES", func(t hub.OrgToken) (string, int) {
+			s := getRepositories(t.Resources)
+			return s, len(s)
+		}},
+		{"LAST USED", func(t hub.OrgToken) (string, int) {
+			s := getLastUsed(t.LastUsed)
+			return s, len(s)
+		}},
+		{"EXPIRES", func(t hub.OrgToken) (string, int) {
+			s := getExpiresAt(t.ExpiresAt)
+			return s, len(s)
+		}},
+		{"ACTIVE", func(t hub.OrgToken) (string, int) {
+			s := fmt.Sprintf("%v", t.IsActive)
+			return s, len(s)
+		}},
+	}
+	// optionalColumns are only printed when selected with --columns
+	optionalColumns = []column{
+		{"DESCRIPTION", func(t hub.OrgToken) (string, int) { return t.Description, len(t.Description) }},
+		{"CREATED BY", func(t hub.OrgToken) (string, int) { return t.CreatedBy, len(t.CreatedBy) }},
+		{"CREATED", func(t hub.OrgToken) (string, int) {
+			s := units.HumanDuration(time.Since(t.CreatedAt))
+			return s, len(s)
+		}},
+	}
+)
+
+type column struct {
+	header string
+	value  func(t hub.OrgToken) (string, int)
+}
+
+func columnHeader(c column) string {
+	return c.header
+}
+
+func columnValue(c column, o hub.OrgToken) string {
+	value, _ := c.value(o)
+	return value
+}
+
+type listOptions struct {
+	format.Option
+	tabwriter.Options
+	all bool
+}
+
+func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:                   lsName + " [OPTIONS] ORGANIZATION",
+		Aliases:               []string{"list"},
+		Short:                 "List the access tokens of an organization",
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Organizations(hubClient)),
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, lsName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runList(streams, hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch all available tokens")
+	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(columnHeader, defaultColumns, optionalColumns))
+	return cmd
+}
+
+func runList(streams command.Streams, hubClient *hub.Client, opts listOptions, organization string) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, defaultColumns, optionalColumns, columnHeader)
+	if err != nil {
+		return err
+	}
+	if opts.all {
+		if err := hubClient.Update(hub.WithAllElements()); err != nil {
+			return err
+		}
+	}
+	tokens, total, err := hubClient.GetOrgTokens(organization)
+	if err != nil {
+		return err
+	}
+	list := format.List{
+		Kind:   schema.KindOrgToken,
+		Total:  total,
+		Values: tokens,
+		Items:  schema.NewOrgTokens(tokens),
+	}
+	return opts.PrintList(streams.Out(), list, printTokens(total, columns, &opts.Options), tabwriter.Records(columns, columnHeader, columnValue))
+}
+
+func printTokens(total int, columns []column, table *tabwriter.Options) format.PrettyPrinter {
+	return func(out io.Writer, values interface{}) error {
+		tokens := values.([]hub.OrgToken)
+		tw := table.New(out, "    ")
+		for _, column := range columns {
+			tw.Column(ansi.Header(column.header), len(column.header))
+		}
+
+		tw.Line()
+		for _, token := range tokens {
+			for _, column := range columns {
+				value, width := column.value(token)
+				tw.Column(value, width)
+			}
+			tw.Line()
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		if len(tokens) < total {
+			fmt.Fprintln(out, ansi.Info(fmt.Sprintf("%v/%v listed, use --all flag to show all", len(tokens), total)))
+		}
+		return nil
+	}
+}
+
+// getRepositories summarizes the repositories the token can access with their permissions
+func getRepositories(resources []hub.OrgTokenResource) string {
+	var repositories []string
+	for _, resource := range resources {
+		if resource.Type != hub.ResourceTypeRepository {
+			continue
+		}
+		repositories = append(repositories, fmt.Sprintf("%s (%s)", resource.Path, getPermission(resource.Scopes)))
+	}
+	return strings.Join(repositories, ", ")
+}
+
+func getLastUsed(t time.Time) string {
+	if t.IsZero() {
+		return "Never"
+	}
+	return fmt.Sprintf("%s ago", units.HumanDuration(time.Since(t)))
+}
+
+func getExpiresAt(t time.Time) string {
+	if t.IsZero() {
+		return "Never"
+	}
+	if t.Before(time.Now()) {
+		return fmt.Sprintf("Expired %s ago", units.HumanDuration(time.Since(t)))
+	}
+	return fmt.Sprintf("In %s", units.HumanDuration(time.Until(t)))
+}
@@ -0,0 +1,91 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tokens
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	rmName = "rm"
+)
+
+type rmOptions struct {
+	force bool
+}
+
+func newRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts rmOptions
+	cmd := &cobra.Command{
+		Use:                   rmName + " [OPTIONS] ORGANIZATION TOKEN_ID",
+		Short:                 "Delete an access token of an organization",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.OrgTokens(hubClient)),
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, rmName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runRm(streams, hubClient, opts, args[0], args[1])
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Force deletion of the token")
+	return cmd
+}
+
+func runRm(streams command.Streams, hubClient *hub.Client, opts rmOptions, organization, tokenID string) error {
+	id, err := uuid.Parse(tokenID)
+	if err != nil {
+		return errdef.Validation(err)
+	}
+
+	if !opts.force {
+		fmt.Fprintf(streams.Out(), ansi.Warn("WARNING: This action is irreversible.")+`
+By confirming, you will permanently delete the access token of the organization.
+Removing the token will invalidate the credentials of all the Docker clients currently authenticated with it.
+
+Please type the organization name %q to confirm token deletion: `, organization)
+		reader := bufio.NewReader(streams.In())
+		input, _ := reader.ReadString('\n')
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input != strings.ToLower(organization) {
+			return fmt.Errorf("%q differs from the organization name, deletion aborted", input)
+		}
+	}
+
+	if err := hubClient.RemoveOrgToken(organization, id.String()); err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out(), ansi.Emphasise("Access token deleted"), id)
+	return nil
+}
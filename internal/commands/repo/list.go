@@ -27,9 +27,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/format/tabwriter"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -63,6 +65,11 @@ var (
 			return s, len(s)
 		}},
 	}
+	// optionalColumns are only printed when selected with --columns
+	optionalColumns = []column{
+		{"NAMESPACE", func(r hub.Repository) (string, int) { return r.Namespace, len(r.Namespace) }},
+		{"TYPE", func(r hub.Repository) (string, int) { return string(r.Type), len(r.Type) }},
+	}
 )
 
 type column struct {
@@ -70,8 +77,18 @@ type column struct {
 	value  func(t hub.Repository) (string, int)
 }
 
+func columnHeader(c column) string {
+	return c.header
+}
+
+func columnValue(c column, r hub.Repository) string {
+	value, _ := c.value(r)
+	return value
+}
+
 type listOptions struct {
 	format.Option
+	tabwriter.Options
 	all bool
 }
 
@@ -82,6 +99,7 @@ func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 		Aliases:               []string{"list"},
 		Short:                 "List all the repositories from your account or an organization",
 		Args:                  cli.RequiresMaxArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Namespaces(hubClient)),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, listName)
@@ -92,10 +110,15 @@ func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 	}
 	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch all available repositories")
 	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(columnHeader, defaultColumns, optionalColumns))
 	return cmd
 }
 
 func runList(streams command.Streams, hubClient *hub.Client, opts listOptions, args []string) error {
+	columns, err := tabwriter.SelectColumns(&opts.Options, defaultColumns, optionalColumns, columnHeader)
+	if err != nil {
+		return err
+	}
 	account := hubClient.AuthConfig.Username
 	if opts.all {
 		if err := hubClient.Update(hub.WithAllElements()); err != nil {
@@ -105,27 +128,44 @@ func runList(streams command.Streams, hubClient *hub.Client, opts listOptions, a
 	if len(args) > 0 {
 		account = args[0]
 	}
+	if opts.IsStreaming() {
+		// Print the repositories page by page, as soon as they are fetched
+		if err := hubClient.Update(hub.WithPageHandler(func(page interface{}) error {
+			repositories := page.([]hub.Repository)
+			return opts.PrintList(streams.Out(), format.List{Kind: schema.KindRepository, Values: repositories, Items: schema.NewRepositories(repositories)}, nil, nil)
+		})); err != nil {
+			return err
+		}
+		_, _, err := hubClient.GetRepositories(account)
+		return err
+	}
 	repositories, total, err := hubClient.GetRepositories(account)
 	if err != nil {
 		return err
 	}
 
-	return opts.Print(streams.Out(), repositories, printRepositories(total))
+	list := format.List{
+		Kind:   schema.KindRepository,
+		Total:  total,
+		Values: repositories,
+		Items:  schema.NewRepositories(repositories),
+	}
+	return opts.PrintList(streams.Out(), list, printRepositories(total, columns, &opts.Options), tabwriter.Records(columns, columnHeader, columnValue))
 }
 
-func printRepositories(total int) format.PrettyPrinter {
+func printRepositories(total int, columns []column, table *tabwriter.Options) format.PrettyPrinter {
 	return func(out io.Writer, values interface{}) error {
 		repositories := values.([]hub.Repository)
-		tw := tabwriter.New(out, "    ")
+		tw := table.New(out, "    ")
 
-		for _, column := range defaultColumns {
+		for _, column := range columns {
 			tw.Column(ansi.Header(column.header), len(column.header))
 		}
 
 		tw.Line()
 
 		for _, repository := range repositories {
-			for _, column := range defaultColumns {
+			for _, column := range columns {
 				value, width := column.value(repository)
 				tw.Column(value, width)
 			}
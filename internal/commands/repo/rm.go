@@ -24,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/errdef"
 
 	"github.com/distribution/reference"
@@ -50,6 +51,7 @@ func newRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *co
 		Use:                   rmName + " [OPTIONS] NAMESPACE/REPOSITORY",
 		Short:                 "Delete a repository",
 		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Repositories(hubClient)),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, rmName)
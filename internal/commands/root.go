@@ -18,6 +18,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/docker/cli/cli"
@@ -26,12 +27,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal"
-	"github.com/docker/hub-tool/internal/ansi"
 	"github.com/docker/hub-tool/internal/commands/account"
 	"github.com/docker/hub-tool/internal/commands/org"
 	"github.com/docker/hub-tool/internal/commands/repo"
 	"github.com/docker/hub-tool/internal/commands/tag"
 	"github.com/docker/hub-tool/internal/commands/token"
+	"github.com/docker/hub-tool/internal/errdef"
 	"github.com/docker/hub-tool/internal/login"
 	"github.com/docker/hub-tool/pkg/credentials"
 	"github.com/docker/hub-tool/pkg/hub"
@@ -41,21 +42,34 @@ type options struct {
 	showVersion bool
 	trace       bool
 	verbose     bool
+	errorFormat string
 }
 
 var (
-	anonCmds = []string{"version", "help", "login", "logout"}
+	anonCmds       = []string{"version", "help", "login", "logout", completionName}
+	completionCmds = []string{cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd}
 )
 
 // NewRootCmd returns the main command
 func NewRootCmd(streams command.Streams, hubClient *hub.Client, store credentials.Store, name string) *cobra.Command {
 	var flags options
 	cmd := &cobra.Command{
-		Use:                   name,
-		Short:                 "Docker Hub Tool",
-		Long:                  `A tool to manage your Docker Hub images`,
+		Use:   name,
+		Short: "Docker Hub Tool",
+		Long: `A tool to manage your Docker Hub images
+
+Exit codes:
+  1    general error
+  2    invalid argument, flag or value
+  3    authentication required
+  4    operation not permitted
+  5    resource not found
+  6    rate limited by Docker Hub
+  130  operation canceled`,
 		Annotations:           map[string]string{},
 		SilenceUsage:          true,
+		SilenceErrors:         true,
+		CompletionOptions:     cobra.CompletionOptions{DisableDefaultCmd: true},
 		DisableFlagsInUseLine: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if flags.trace {
@@ -63,9 +77,16 @@ func NewRootCmd(streams command.Streams, hubClient *hub.Client, store credential
 			} else if flags.verbose {
 				log.SetLevel(log.DebugLevel)
 			}
+			if flags.errorFormat != "text" && flags.errorFormat != "json" {
+				return errdef.Validation(fmt.Errorf("unsupported error format %q: should be one of text, json", flags.errorFormat))
+			}
 			if flags.showVersion {
 				return nil
 			}
+			if contains(completionCmds, cmd.Name()) {
+				refreshCompletionToken(hubClient, store)
+				return nil
+			}
 			if contains(anonCmds, cmd.Name()) {
 				return nil
 			}
@@ -76,8 +97,7 @@ func NewRootCmd(streams command.Streams, hubClient *hub.Client, store credential
 			}
 
 			if ac.Username == "" {
-				log.Fatal(ansi.Error(`You need to be logged in to Docker Hub to use this tool.
-Please login to Docker Hub using the "hub-tool login" command.`))
+				return errdef.ErrAuthRequired
 			}
 
 			if cmd.Annotations["sudo"] == "true" {
@@ -104,6 +124,10 @@ Please login to Docker Hub using the "hub-tool login" command.`))
 	cmd.PersistentFlags().BoolVar(&flags.verbose, "verbose", false, "Print logs")
 	cmd.PersistentFlags().BoolVar(&flags.trace, "trace", false, "Print trace logs")
 	_ = cmd.PersistentFlags().MarkHidden("trace")
+	cmd.PersistentFlags().StringVar(&flags.errorFormat, "error-format", "text", `Format of the errors written to stderr: "text" or "json"`)
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return errdef.Validation(err)
+	})
 
 	cmd.AddCommand(
 		newLoginCmd(streams, store, hubClient),
@@ -114,10 +138,24 @@ Please login to Docker Hub using the "hub-tool login" command.`))
 		repo.NewRepoCmd(streams, hubClient),
 		tag.NewTagCmd(streams, hubClient),
 		newVersionCmd(streams),
+		newCompletionCmd(streams),
 	)
+	markArgsErrors(cmd)
 	return cmd
 }
 
+// markArgsErrors marks the errors returned by the positional arguments checks as validation errors
+func markArgsErrors(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			return errdef.Validation(args(cmd, a))
+		}
+	}
+	for _, c := range cmd.Commands() {
+		markArgsErrors(c)
+	}
+}
+
 func contains(haystack []string, needle string) bool {
 	for _, v := range haystack {
 		if needle == v {
@@ -139,6 +177,32 @@ func newVersionCmd(streams command.Streams) *cobra.Command {
 	}
 }
 
+// refreshCompletionToken renews an expired token before completing arguments from Hub.
+// The shell reads the candidates from the standard output, so completion can't prompt
+// for a 2FA code, it completes nothing until another command refreshes the token.
+func refreshCompletionToken(hubClient *hub.Client, store credentials.Store) {
+	ac, err := store.GetAuth()
+	if err != nil || ac.Username == "" || !ac.TokenExpired() {
+		return
+	}
+	token, refreshToken, err := hubClient.Login(ac.Username, ac.Password, func() (string, error) {
+		return "", errors.New("2FA code required")
+	})
+	if err != nil {
+		log.Debugf("Could not refresh the token for completion: %s", err)
+		return
+	}
+	if err := hubClient.Update(hub.WithHubToken(token)); err != nil {
+		return
+	}
+	_ = store.Store(credentials.Auth{
+		Username:     ac.Username,
+		Password:     ac.Password,
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
+
 func tryLogin(ctx context.Context, streams command.Streams, hubClient *hub.Client, ac *credentials.Auth, store credentials.Store) error {
 	token, refreshToken, err := login.Login(ctx, streams, hubClient, ac.Username, ac.Password)
 	if err != nil {
@@ -0,0 +1,180 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/containerd/containerd/images"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/docker/hub-tool/internal/ansi"
+)
+
+// mediaTypeArtifactManifest is the artifact manifest of the OCI 1.1 release
+// candidates, superseded by image manifests with an artifactType
+const mediaTypeArtifactManifest = "application/vnd.oci.artifact.manifest.v1+json"
+
+// Artifact is an OCI artifact, a manifest which does not describe a container image
+type Artifact struct {
+	Name       string
+	Manifest   ocispec.Manifest
+	Descriptor ocispec.Descriptor
+}
+
+type artifactManifest struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType,omitempty"`
+	Blobs        []ocispec.Descriptor `json:"blobs,omitempty"`
+	Subject      *ocispec.Descriptor  `json:"subject,omitempty"`
+	Annotations  map[string]string    `json:"annotations,omitempty"`
+}
+
+// isArtifact returns true if the manifest does not reference an image config
+func isArtifact(manifest ocispec.Manifest) bool {
+	if manifest.ArtifactType != "" {
+		return true
+	}
+	return manifest.Config.MediaType != images.MediaTypeDockerSchema2Config && manifest.Config.MediaType != ocispec.MediaTypeImageConfig
+}
+
+// readArtifact reads an image manifest with an artifact type, or an artifact manifest
+func readArtifact(raw []byte, descriptor ocispec.Descriptor, name string) (*Artifact, error) {
+	var manifest ocispec.Manifest
+	if descriptor.MediaType == mediaTypeArtifactManifest {
+		var artifact artifactManifest
+		if err := json.Unmarshal(raw, &artifact); err != nil {
+			return nil, err
+		}
+		manifest = ocispec.Manifest{
+			MediaType:    artifact.MediaType,
+			ArtifactType: artifact.ArtifactType,
+			Layers:       artifact.Blobs,
+			Subject:      artifact.Subject,
+			Annotations:  artifact.Annotations,
+		}
+	} else if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, err
+	}
+	return &Artifact{Name: name, Manifest: manifest, Descriptor: descriptor}, nil
+}
+
+func formatArtifact(streams command.Streams, format string, raw []byte, descriptor ocispec.Descriptor, name string) error {
+	artifact, err := readArtifact(raw, descriptor, name)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "raw":
+		_, err := fmt.Fprintf(streams.Out(), "%s", raw) // avoid newline to keep digest
+		return err
+	case "json":
+		buf, err := json.MarshalIndent(artifact, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(streams.Out(), string(buf))
+		return err
+	case "":
+		return printArtifact(streams.Out(), artifact)
+	default:
+		return printTemplate(streams.Out(), format, artifact)
+	}
+}
+
+func printArtifact(out io.Writer, artifact *Artifact) error {
+	fmt.Fprintf(out, ansi.Title("Artifact:")+"\n")
+	fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s\n", artifact.Name)
+	fmt.Fprintf(out, ansi.Key("MediaType:")+"\t%s\n", artifact.Descriptor.MediaType)
+	fmt.Fprintf(out, ansi.Key("ArtifactType:")+"\t%s\n", artifactType(artifact.Manifest))
+	fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s\n", artifact.Descriptor.Digest)
+	if artifact.Manifest.Subject != nil {
+		fmt.Fprintf(out, ansi.Key("Subject:")+"\t%s@%s\n", artifact.Name, artifact.Manifest.Subject.Digest)
+	}
+	if len(artifact.Manifest.Annotations) > 0 {
+		printAnnotations(out, artifact.Manifest.Annotations)
+	} else if len(artifact.Descriptor.Annotations) > 0 {
+		printAnnotations(out, artifact.Descriptor.Annotations)
+	}
+	fmt.Fprintf(out, "\n")
+
+	if artifact.Manifest.Config.MediaType != "" {
+		fmt.Fprintf(out, ansi.Title("Config:")+"\n")
+		fmt.Fprintf(out, ansi.Key("MediaType:")+"\t%s\n", artifact.Manifest.Config.MediaType)
+		fmt.Fprintf(out, ansi.Key("Size:")+"\t\t%v\n", units.HumanSize(float64(artifact.Manifest.Config.Size)))
+		fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s\n", artifact.Manifest.Config.Digest)
+		fmt.Fprintf(out, "\n")
+	}
+
+	fmt.Fprintln(out, ansi.Title("Blobs:"))
+	for i, blob := range artifact.Manifest.Layers {
+		if i != 0 {
+			fmt.Fprintln(out)
+		}
+		printDescriptor(out, blob)
+	}
+	return nil
+}
+
+// artifactType falls back on the config media type, as recommended by the OCI image spec
+func artifactType(manifest ocispec.Manifest) string {
+	if manifest.ArtifactType != "" {
+		return manifest.ArtifactType
+	}
+	return manifest.Config.MediaType
+}
+
+func formatDescriptor(streams command.Streams, format string, raw []byte, descriptor ocispec.Descriptor, name string) error {
+	switch format {
+	case "raw":
+		_, err := fmt.Fprintf(streams.Out(), "%s", raw) // avoid newline to keep digest
+		return err
+	case "json":
+		buf, err := json.MarshalIndent(descriptor, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(streams.Out(), string(buf))
+		return err
+	case "":
+		out := streams.Out()
+		fmt.Fprintf(out, ansi.Title("Descriptor:")+"\n")
+		fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s\n", name)
+		printDescriptor(out, descriptor)
+		fmt.Fprintf(out, "\n")
+		fmt.Fprintln(out, ansi.Warn(fmt.Sprintf("Unsupported media type %q, use --format raw to print its content", descriptor.MediaType)))
+		return nil
+	default:
+		return printTemplate(streams.Out(), format, descriptor)
+	}
+}
+
+func printDescriptor(out io.Writer, descriptor ocispec.Descriptor) {
+	fmt.Fprintf(out, ansi.Key("MediaType:")+"\t%s\n", descriptor.MediaType)
+	fmt.Fprintf(out, ansi.Key("Size:")+"\t\t%v\n", units.HumanSize(float64(descriptor.Size)))
+	fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s\n", descriptor.Digest)
+	if descriptor.ArtifactType != "" {
+		fmt.Fprintf(out, ansi.Key("ArtifactType:")+"\t%s\n", descriptor.ArtifactType)
+	}
+	if len(descriptor.Annotations) > 0 {
+		printAnnotations(out, descriptor.Annotations)
+	}
+}
@@ -0,0 +1,347 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/command"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/docker/hub-tool/internal/ansi"
+)
+
+const (
+	// annotations set by BuildKit on the attestation manifests of an index
+	annotationReferenceType   = "vnd.docker.reference.type"
+	annotationReferenceDigest = "vnd.docker.reference.digest"
+	referenceTypeAttestation  = "attestation-manifest"
+
+	annotationPredicateType = "in-toto.io/predicate-type"
+	mediaTypeInToto         = "application/vnd.in-toto+json"
+)
+
+// Attestations lists the attestations of the images of a tag and the
+// artifacts, like signatures or SBOMs, referring to it
+type Attestations struct {
+	Name      string
+	Digest    digest.Digest
+	Images    []ImageAttestations
+	Referrers []ocispec.Descriptor
+}
+
+// ImageAttestations are the in-toto statements attached to an image
+type ImageAttestations struct {
+	Platform   *ocispec.Platform
+	Digest     digest.Digest
+	Manifest   digest.Digest
+	Statements []Statement
+}
+
+// Statement is an in-toto statement
+type Statement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []Subject       `json:"subject"`
+	Predicate     json.RawMessage `json:"predicate,omitempty"`
+}
+
+// Subject is a software artifact an in-toto statement is about
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Builder returns the builder ID of a SLSA provenance statement, if any
+func (s Statement) Builder() string {
+	var predicate struct {
+		// SLSA provenance v0.2
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		// SLSA provenance v1
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+	}
+	if err := json.Unmarshal(s.Predicate, &predicate); err != nil {
+		return ""
+	}
+	if predicate.Builder.ID != "" {
+		return predicate.Builder.ID
+	}
+	return predicate.RunDetails.Builder.ID
+}
+
+// isAttestationManifest returns true if the descriptor points to a BuildKit attestation manifest
+func isAttestationManifest(descriptor ocispec.Descriptor) bool {
+	return descriptor.Annotations[annotationReferenceType] == referenceTypeAttestation
+}
+
+// attestationsBySubject groups the attestation manifests of an index by the
+// digest of the image they describe
+func attestationsBySubject(index ocispec.Index) map[digest.Digest][]ocispec.Descriptor {
+	attestations := map[digest.Digest][]ocispec.Descriptor{}
+	for _, m := range index.Manifests {
+		if !isAttestationManifest(m) {
+			continue
+		}
+		subject := digest.Digest(m.Annotations[annotationReferenceDigest])
+		attestations[subject] = append(attestations[subject], m)
+	}
+	return attestations
+}
+
+// readStatement decodes an in-toto statement
+func readStatement(raw []byte) (Statement, error) {
+	var statement Statement
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return Statement{}, err
+	}
+	if statement.PredicateType == "" {
+		return Statement{}, fmt.Errorf("invalid in-toto statement: missing predicate type")
+	}
+	return statement, nil
+}
+
+func readAttestations(ctx context.Context, hosts docker.RegistryHosts, resolver remotes.Resolver,
+	ref reference.Named, descriptor ocispec.Descriptor, raw []byte, platform *ocispec.Platform) (*Attestations, error) {
+	attestations := &Attestations{
+		Name:   ref.Name(),
+		Digest: descriptor.Digest,
+	}
+	if descriptor.MediaType == images.MediaTypeDockerSchema2ManifestList || descriptor.MediaType == ocispec.MediaTypeImageIndex {
+		var index ocispec.Index
+		if err := json.Unmarshal(raw, &index); err != nil {
+			return nil, err
+		}
+		var matcher platforms.Matcher
+		if platform != nil {
+			matcher = platforms.NewMatcher(*platform)
+		}
+		bySubject := attestationsBySubject(index)
+		for _, m := range index.Manifests {
+			if isAttestationManifest(m) {
+				continue
+			}
+			if matcher != nil && (m.Platform == nil || !matcher.Match(*m.Platform)) {
+				continue
+			}
+			for _, a := range bySubject[m.Digest] {
+				statements, err := readAttestationManifest(ctx, resolver, ref.Name(), a, nil)
+				if err != nil {
+					return nil, err
+				}
+				attestations.Images = append(attestations.Images, ImageAttestations{
+					Platform:   m.Platform,
+					Digest:     m.Digest,
+					Manifest:   a.Digest,
+					Statements: statements,
+				})
+			}
+		}
+	}
+
+	referrers, err := getReferrers(ctx, hosts, resolver, ref, descriptor.Digest)
+	if err != nil {
+		return nil, err
+	}
+	attestations.Referrers = referrers
+	return attestations, nil
+}
+
+// readAttestationManifest reads the in-toto statements of an attestation
+// manifest, only fetching the ones whose predicate type matches if a filter is given
+func readAttestationManifest(ctx context.Context, resolver remotes.Resolver, name string, descriptor ocispec.Descriptor,
+	filter func(predicateType string) bool) ([]Statement, error) {
+	raw, err := getBlob(ctx, resolver, fmt.Sprintf("%s@%s", name, descriptor.Digest), descriptor)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, err
+	}
+	var statements []Statement
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaTypeInToto {
+			continue
+		}
+		if filter != nil && !filter(layer.Annotations[annotationPredicateType]) {
+			continue
+		}
+		rawStatement, err := getBlob(ctx, resolver, fmt.Sprintf("%s@%s", name, layer.Digest), layer)
+		if err != nil {
+			return nil, err
+		}
+		statement, err := readStatement(rawStatement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode attestation %s: %w", layer.Digest, err)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+// readImageStatements returns the in-toto statements attached to the image of
+// a tag matching the platform, the platform being optional if there's only one image
+func readImageStatements(ctx context.Context, resolver remotes.Resolver, imageRef string, platform *ocispec.Platform,
+	filter func(predicateType string) bool) (*ImageAttestations, error) {
+	ref, descriptor, raw, err := resolveManifest(ctx, resolver, imageRef)
+	if err != nil {
+		return nil, err
+	}
+	if descriptor.MediaType != images.MediaTypeDockerSchema2ManifestList && descriptor.MediaType != ocispec.MediaTypeImageIndex {
+		return nil, fmt.Errorf("no attestation found for %q: attestations are only attached to image indexes", ref)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return nil, err
+	}
+
+	var candidates []ocispec.Descriptor
+	for _, m := range index.Manifests {
+		if !isAttestationManifest(m) {
+			candidates = append(candidates, m)
+		}
+	}
+	var image *ocispec.Descriptor
+	switch {
+	case platform != nil:
+		image = selectManifest(ocispec.Index{Manifests: candidates}, *platform)
+		if image == nil {
+			return nil, fmt.Errorf("platform %q does not match any available platform for the tag %q", platforms.Format(*platform), ref)
+		}
+	case len(candidates) == 1:
+		image = &candidates[0]
+	default:
+		var available []string
+		for _, c := range candidates {
+			available = append(available, formatPlatform(c.Platform))
+		}
+		return nil, fmt.Errorf("%q is a multi-platform image, select one of %s with --platform", ref, strings.Join(available, ", "))
+	}
+
+	attestations := attestationsBySubject(index)[image.Digest]
+	if len(attestations) == 0 {
+		return nil, fmt.Errorf("no attestation found for %q (%s)", ref, formatPlatform(image.Platform))
+	}
+	result := &ImageAttestations{
+		Platform: image.Platform,
+		Digest:   image.Digest,
+		Manifest: attestations[0].Digest,
+	}
+	for _, a := range attestations {
+		statements, err := readAttestationManifest(ctx, resolver, ref.Name(), a, filter)
+		if err != nil {
+			return nil, err
+		}
+		result.Statements = append(result.Statements, statements...)
+	}
+	return result, nil
+}
+
+func formatAttestations(ctx context.Context, streams command.Streams, hosts docker.RegistryHosts, resolver remotes.Resolver,
+	format string, ref reference.Named, descriptor ocispec.Descriptor, raw []byte, platform *ocispec.Platform) error {
+	attestations, err := readAttestations(ctx, hosts, resolver, ref, descriptor, raw, platform)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+		buf, err := json.MarshalIndent(attestations, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(streams.Out(), string(buf))
+		return err
+	case "":
+		return printAttestations(streams.Out(), attestations)
+	default:
+		return printTemplate(streams.Out(), format, attestations)
+	}
+}
+
+func printAttestations(out io.Writer, attestations *Attestations) error {
+	fmt.Fprintf(out, ansi.Title("Attestations:")+"\n")
+	fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s\n", attestations.Name)
+	fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s\n", attestations.Digest)
+	if len(attestations.Images) == 0 {
+		fmt.Fprintln(out, ansi.Info("No attestation found"))
+	}
+	for _, image := range attestations.Images {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, ansi.Key("Image:")+"\t\t%s@%s\n", attestations.Name, image.Digest)
+		fmt.Fprintf(out, ansi.Key("Platform:")+"\t%s\n", formatPlatform(image.Platform))
+		fmt.Fprintf(out, ansi.Key("Manifest:")+"\t%s\n", image.Manifest)
+		for _, statement := range image.Statements {
+			fmt.Fprintf(out, ansi.Key("Predicate type:")+"\t%s\n", statement.PredicateType)
+			for _, subject := range statement.Subject {
+				fmt.Fprintf(out, "    "+ansi.Key("Subject:")+"\t%s\n", formatSubject(subject))
+			}
+			if builder := statement.Builder(); builder != "" {
+				fmt.Fprintf(out, "    "+ansi.Key("Builder:")+"\t%s\n", builder)
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "\n")
+
+	fmt.Fprintf(out, ansi.Title("Referrers:")+"\n")
+	if len(attestations.Referrers) == 0 {
+		fmt.Fprintln(out, ansi.Info("No referrer found"))
+	}
+	for i, referrer := range attestations.Referrers {
+		if i != 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s@%s\n", attestations.Name, referrer.Digest)
+		fmt.Fprintf(out, ansi.Key("MediaType:")+"\t%s\n", referrer.MediaType)
+		if referrer.ArtifactType != "" {
+			fmt.Fprintf(out, ansi.Key("ArtifactType:")+"\t%s\n", referrer.ArtifactType)
+		}
+		if len(referrer.Annotations) > 0 {
+			printAnnotations(out, referrer.Annotations)
+		}
+	}
+	return nil
+}
+
+func formatSubject(subject Subject) string {
+	algorithms := make([]string, 0, len(subject.Digest))
+	for algorithm := range subject.Digest {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+	if len(algorithms) == 0 {
+		return subject.Name
+	}
+	return fmt.Sprintf("%s %s:%s", subject.Name, algorithms[0], subject.Digest[algorithms[0]])
+}
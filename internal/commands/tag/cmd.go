@@ -37,9 +37,14 @@ func NewTagCmd(streams command.Streams, hubClient *hub.Client) *cobra.Command {
 		RunE:  command.ShowHelp(streams.Err()),
 	}
 	cmd.AddCommand(
+		newCpCmd(streams, hubClient, tagName),
+		newDiffCmd(streams, hubClient, tagName),
 		newInspectCmd(streams, hubClient, tagName),
+		newLatestCmd(streams, hubClient, tagName),
 		newListCmd(streams, hubClient, tagName),
+		newProvenanceCmd(streams, hubClient, tagName),
 		newRmCmd(streams, hubClient, tagName),
+		newSbomCmd(streams, hubClient, tagName),
 	)
 	return cmd
 }
@@ -0,0 +1,114 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+func TestInvalidOptionsExitWithTheUsageCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		run      func() error
+		expected string
+	}{
+		{
+			name:     "list sort column",
+			run:      func() error { return runList(nil, nil, listOptions{sort: "size"}, "user/repo") },
+			expected: `unknown sorting column "size"`,
+		},
+		{
+			name:     "list sort direction",
+			run:      func() error { return runList(nil, nil, listOptions{sort: "name=up"}, "user/repo") },
+			expected: `invalid sorting direction "up"`,
+		},
+		{
+			name:     "list semver sort direction",
+			run:      func() error { return runList(nil, nil, listOptions{sort: "semver=up"}, "user/repo") },
+			expected: `invalid sorting direction "up"`,
+		},
+		{
+			name:     "list limit",
+			run:      func() error { return runList(nil, nil, listOptions{limit: -1}, "user/repo") },
+			expected: "invalid limit -1",
+		},
+		{
+			name: "list filter",
+			run: func() error {
+				return runList(nil, nil, listOptions{filters: []string{"digest=sha256:abc"}}, "user/repo")
+			},
+			expected: `unknown key "digest"`,
+		},
+		{
+			name: "list filter value",
+			run: func() error {
+				return runList(nil, nil, listOptions{filters: []string{"pushed=yesterday"}}, "user/repo")
+			},
+			expected: `"yesterday" is neither a date nor a duration`,
+		},
+		{
+			name: "rm filter",
+			run: func() error {
+				_, err := resolveRmTags(nil, rmOptions{filter: "["}, []string{"user/repo"})
+				return err
+			},
+			expected: `invalid filter "["`,
+		},
+		{
+			name:     "latest constraint",
+			run:      func() error { return runLatest(nil, nil, latestOptions{constraint: ">=x.y"}, "user/repo") },
+			expected: "x.y",
+		},
+		{
+			name: "inspect platform",
+			run: func() error {
+				return runInspect(nil, nil, inspectOptions{platform: "linux/amd64/v3/extra"}, "user/repo:1")
+			},
+			expected: `invalid platform "linux/amd64/v3/extra"`,
+		},
+		{
+			name: "diff platform",
+			run: func() error {
+				return runDiff(nil, nil, diffOptions{platform: "linux/amd64/v3/extra"}, "user/repo:1", "user/repo:2")
+			},
+			expected: `invalid platform "linux/amd64/v3/extra"`,
+		},
+		{
+			name:     "sbom platform",
+			run:      func() error { return runSbom(nil, nil, sbomOptions{platform: "linux/amd64/v3/extra"}, "user/repo:1") },
+			expected: `invalid platform "linux/amd64/v3/extra"`,
+		},
+		{
+			name: "provenance platform",
+			run: func() error {
+				return runProvenance(nil, nil, provenanceOptions{platform: "linux/amd64/v3/extra"}, "user/repo:1")
+			},
+			expected: `invalid platform "linux/amd64/v3/extra"`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.run()
+			assert.ErrorContains(t, err, testCase.expected)
+			assert.Equal(t, errdef.ExitCode(err), 2)
+		})
+	}
+}
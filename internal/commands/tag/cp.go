@@ -0,0 +1,221 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/labels"
+	"github.com/containerd/containerd/remotes"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	cpName = "cp"
+)
+
+// imageCopier copies an image between repositories. Within a registry, blobs
+// are mounted from the source repository, so they never go through the client
+// unless the registry refuses the mount.
+type imageCopier struct {
+	errOut   io.Writer
+	resolver remotes.Resolver
+	fetcher  remotes.Fetcher
+	src      reference.Named
+	dst      reference.Named
+	seen     map[string]bool
+	mounted  int
+	copied   int
+}
+
+func newCpCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   cpName + " SOURCE_REPOSITORY:TAG TARGET_REPOSITORY:TAG",
+		Short:                 "Copy a tag to a new tag, in the same or in another repository, without pulling the image",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.Tags(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, cpName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runCp(streams, hubClient, args[0], args[1])
+		},
+	}
+	return cmd
+}
+
+func runCp(streams command.Streams, hubClient *hub.Client, source, target string) error {
+	ctx := hubClient.Ctx
+	dst, err := reference.ParseNormalizedNamed(target)
+	if err != nil {
+		return err
+	}
+	dstTagged, ok := dst.(reference.NamedTagged)
+	if !ok {
+		return fmt.Errorf("invalid reference %q: tag must be specified", target)
+	}
+
+	resolver := newResolver(hubClient)
+	src, descriptor, raw, err := resolveManifest(ctx, resolver, source)
+	if err != nil {
+		return err
+	}
+	fetcher, err := resolver.Fetcher(ctx, src.String())
+	if err != nil {
+		return err
+	}
+	copier := &imageCopier{
+		errOut:   streams.Err(),
+		resolver: resolver,
+		fetcher:  fetcher,
+		src:      reference.TrimNamed(src),
+		dst:      reference.TrimNamed(dstTagged),
+		seen:     map[string]bool{},
+	}
+	if err := copier.copy(ctx, descriptor, raw, dstTagged.String()); err != nil {
+		return err
+	}
+
+	if copier.mounted+copier.copied > 0 {
+		fmt.Fprintf(streams.Out(), "%d blob(s) mounted from %s, %d blob(s) copied\n", copier.mounted, reference.FamiliarName(copier.src), copier.copied)
+	}
+	fmt.Fprintln(streams.Out(), ansi.Emphasise(fmt.Sprintf("Copied %s to %s", reference.FamiliarString(src), reference.FamiliarString(dstTagged))))
+	fmt.Fprintf(streams.Out(), ansi.Key("Digest:")+"\t%s\n", descriptor.Digest)
+	return nil
+}
+
+// copy pushes the content referenced by a manifest or an index to the target
+// repository, then the manifest or index itself to the target reference
+func (c *imageCopier) copy(ctx context.Context, descriptor ocispec.Descriptor, raw []byte, target string) error {
+	// Within a repository, all the referenced content already exists
+	if c.src.Name() != c.dst.Name() {
+		switch descriptor.MediaType {
+		case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+			var index ocispec.Index
+			if err := json.Unmarshal(raw, &index); err != nil {
+				return err
+			}
+			for _, m := range index.Manifests {
+				childRaw, err := getBlob(ctx, c.resolver, c.src.String(), m)
+				if err != nil {
+					return err
+				}
+				if err := c.copy(ctx, m, childRaw, fmt.Sprintf("%s@%s", c.dst.Name(), m.Digest)); err != nil {
+					return err
+				}
+			}
+		case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+			var manifest ocispec.Manifest
+			if err := json.Unmarshal(raw, &manifest); err != nil {
+				return err
+			}
+			for _, blob := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+				if err := c.pushBlob(ctx, blob); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported media type %q", descriptor.MediaType)
+		}
+	}
+	return c.pushManifest(ctx, descriptor, raw, target)
+}
+
+func (c *imageCopier) pushBlob(ctx context.Context, blob ocispec.Descriptor) error {
+	// Platforms of an index often share layers
+	if c.seen[blob.Digest.String()] {
+		return nil
+	}
+	c.seen[blob.Digest.String()] = true
+	pusher, err := c.resolver.Pusher(ctx, c.dst.Name())
+	if err != nil {
+		return err
+	}
+	// A blob can only be mounted from a repository of the same registry
+	mount := reference.Domain(c.src) == reference.Domain(c.dst)
+	if mount {
+		// The distribution source label makes the pusher try a cross-repository mount first,
+		// it is keyed by the registry host without its port
+		annotations := map[string]string{}
+		for k, v := range blob.Annotations {
+			annotations[k] = v
+		}
+		host := (&url.URL{Host: reference.Domain(c.src)}).Hostname()
+		annotations[fmt.Sprintf("%s.%s", labels.LabelDistributionSource, host)] = reference.Path(c.src)
+		blob.Annotations = annotations
+	}
+
+	w, err := pusher.Push(ctx, blob)
+	if errdefs.IsAlreadyExists(err) {
+		if mount {
+			c.mounted++
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer w.Close() //nolint:errcheck
+
+	if mount {
+		fmt.Fprintln(c.errOut, ansi.Warn(fmt.Sprintf("Could not mount %s from %s, copying it through this machine", blob.Digest, reference.FamiliarName(c.src))))
+	}
+	rc, err := c.fetcher.Fetch(ctx, blob)
+	if err != nil {
+		return err
+	}
+	defer rc.Close() //nolint:errcheck
+	if err := content.Copy(ctx, w, rc, blob.Size, blob.Digest); err != nil {
+		return err
+	}
+	c.copied++
+	return nil
+}
+
+func (c *imageCopier) pushManifest(ctx context.Context, descriptor ocispec.Descriptor, raw []byte, target string) error {
+	pusher, err := c.resolver.Pusher(ctx, target)
+	if err != nil {
+		return err
+	}
+	w, err := pusher.Push(ctx, descriptor)
+	if errdefs.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer w.Close() //nolint:errcheck
+	return content.Copy(ctx, w, bytes.NewReader(raw), descriptor.Size, descriptor.Digest)
+}
@@ -0,0 +1,272 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+)
+
+type registryContent struct {
+	mediaType string
+	data      []byte
+}
+
+// fakeRegistry serves the manifests and blobs of its repositories over the
+// distribution API, refusing cross-repository mounts if asked to
+type fakeRegistry struct {
+	mu          sync.Mutex
+	refuseMount bool
+	repos       map[string]map[string]registryContent
+	calls       []string
+	mounts      int
+	uploads     int
+}
+
+func newFakeRegistry(t *testing.T) (*fakeRegistry, string) {
+	r := &fakeRegistry{repos: map[string]map[string]registryContent{}}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return r, strings.TrimPrefix(server.URL, "http://")
+}
+
+// add stores content in a repository under its digest and the given tags
+func (r *fakeRegistry) add(repo string, mediaType string, data []byte, tags ...string) ocispec.Descriptor {
+	descriptor := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(data), Size: int64(len(data))}
+	r.store(repo, descriptor.Digest.String(), registryContent{mediaType: mediaType, data: data})
+	for _, tag := range tags {
+		r.store(repo, tag, registryContent{mediaType: mediaType, data: data})
+	}
+	return descriptor
+}
+
+func (r *fakeRegistry) store(repo, ref string, c registryContent) {
+	if r.repos[repo] == nil {
+		r.repos[repo] = map[string]registryContent{}
+	}
+	r.repos[repo][ref] = c
+}
+
+func (r *fakeRegistry) get(repo, ref string) (registryContent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.repos[repo][ref]
+	return c, ok
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Uploads are streamed while the client fetches from the source repository
+	data, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req.URL.Path == "/v2/" {
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	var repo, kind, ref string
+	for _, k := range []string{"/manifests/", "/blobs/uploads/", "/blobs/"} {
+		if i := strings.Index(path, k); i >= 0 {
+			repo, kind, ref = path[:i], strings.Trim(k, "/"), path[i+len(k):]
+			break
+		}
+	}
+	if kind != "" && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		r.calls = append(r.calls, fmt.Sprintf("%s %s %s", req.Method, repo, kind))
+	}
+	switch {
+	case kind == "blobs/uploads" && req.Method == http.MethodPost:
+		from, mount := req.URL.Query().Get("from"), req.URL.Query().Get("mount")
+		if mount != "" {
+			r.mounts++
+		}
+		if c, ok := r.repos[from][mount]; ok && !r.refuseMount {
+			r.store(repo, mount, c)
+			w.Header().Set("Docker-Content-Digest", mount)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d", repo, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "blobs/uploads" && req.Method == http.MethodPut:
+		r.store(repo, req.URL.Query().Get("digest"), registryContent{data: data})
+		w.Header().Set("Docker-Content-Digest", req.URL.Query().Get("digest"))
+		w.WriteHeader(http.StatusCreated)
+	case kind == "manifests" && req.Method == http.MethodPut:
+		c := registryContent{mediaType: req.Header.Get("Content-Type"), data: data}
+		r.store(repo, ref, c)
+		r.store(repo, digest.FromBytes(data).String(), c)
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(data).String())
+		w.WriteHeader(http.StatusCreated)
+	case kind != "" && (req.Method == http.MethodGet || req.Method == http.MethodHead):
+		c, ok := r.repos[repo][ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if c.mediaType != "" {
+			w.Header().Set("Content-Type", c.mediaType)
+		}
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(c.data).String())
+		w.Header().Set("Content-Length", strconv.Itoa(len(c.data)))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(c.data)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// addMultiPlatformImage stores an index of two images sharing their base layer
+func addMultiPlatformImage(t *testing.T, r *fakeRegistry, repo, tag string) ocispec.Descriptor {
+	base := r.add(repo, ocispec.MediaTypeImageLayer, []byte("base layer"))
+	var manifests []ocispec.Descriptor
+	for _, arch := range []string{"amd64", "arm64"} {
+		config := r.add(repo, ocispec.MediaTypeImageConfig, []byte(fmt.Sprintf(`{"architecture":%q,"os":"linux"}`, arch)))
+		layer := r.add(repo, ocispec.MediaTypeImageLayer, []byte("app layer for "+arch))
+		raw, err := json.Marshal(ocispec.Manifest{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    config,
+			Layers:    []ocispec.Descriptor{base, layer},
+		})
+		assert.NilError(t, err)
+		manifest := r.add(repo, ocispec.MediaTypeImageManifest, raw)
+		manifest.Platform = &ocispec.Platform{OS: "linux", Architecture: arch}
+		manifests = append(manifests, manifest)
+	}
+	raw, err := json.Marshal(ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: manifests})
+	assert.NilError(t, err)
+	return r.add(repo, ocispec.MediaTypeImageIndex, raw, tag)
+}
+
+func copyImage(t *testing.T, source, target string) (*imageCopier, string) {
+	ctx := context.Background()
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(docker.WithPlainHTTP(docker.MatchAllHosts)),
+	})
+	src, descriptor, raw, err := resolveManifest(ctx, resolver, source)
+	assert.NilError(t, err)
+	fetcher, err := resolver.Fetcher(ctx, src.String())
+	assert.NilError(t, err)
+	dst, err := reference.ParseNormalizedNamed(target)
+	assert.NilError(t, err)
+	errOut := bytes.NewBuffer(nil)
+	copier := &imageCopier{
+		errOut:   errOut,
+		resolver: resolver,
+		fetcher:  fetcher,
+		src:      reference.TrimNamed(src),
+		dst:      reference.TrimNamed(dst),
+		seen:     map[string]bool{},
+	}
+	assert.NilError(t, copier.copy(ctx, descriptor, raw, dst.String()))
+	return copier, errOut.String()
+}
+
+func TestCpMountsBlobsFromTheSourceRepository(t *testing.T) {
+	registry, host := newFakeRegistry(t)
+	index := addMultiPlatformImage(t, registry, "user/src", "1.0")
+
+	copier, warnings := copyImage(t, host+"/user/src:1.0", host+"/user/dst:1.0")
+	assert.Equal(t, copier.mounted, 5)
+	assert.Equal(t, copier.copied, 0)
+	assert.Equal(t, warnings, "")
+
+	pushed, ok := registry.get("user/dst", "1.0")
+	assert.Assert(t, ok)
+	assert.Equal(t, digest.FromBytes(pushed.data), index.Digest)
+	assert.Equal(t, pushed.mediaType, ocispec.MediaTypeImageIndex)
+	assert.DeepEqual(t, registry.calls, []string{
+		// Config, base layer and layer of the first platform, then the manifest
+		"POST user/dst blobs/uploads",
+		"POST user/dst blobs/uploads",
+		"POST user/dst blobs/uploads",
+		"PUT user/dst manifests",
+		// The base layer was already mounted
+		"POST user/dst blobs/uploads",
+		"POST user/dst blobs/uploads",
+		"PUT user/dst manifests",
+		"PUT user/dst manifests",
+	})
+}
+
+func TestCpCopiesBlobsWhenTheMountIsRefused(t *testing.T) {
+	registry, host := newFakeRegistry(t)
+	registry.refuseMount = true
+	addMultiPlatformImage(t, registry, "user/src", "1.0")
+
+	copier, warnings := copyImage(t, host+"/user/src:1.0", host+"/user/dst:1.0")
+	assert.Equal(t, copier.mounted, 0)
+	assert.Equal(t, copier.copied, 5)
+	assert.Equal(t, strings.Count(warnings, "copying it through this machine"), 5)
+
+	layer, ok := registry.get("user/dst", digest.FromBytes([]byte("base layer")).String())
+	assert.Assert(t, ok)
+	assert.Equal(t, string(layer.data), "base layer")
+	_, ok = registry.get("user/dst", "1.0")
+	assert.Assert(t, ok)
+}
+
+func TestCpCopiesBlobsBetweenRegistries(t *testing.T) {
+	source, sourceHost := newFakeRegistry(t)
+	index := addMultiPlatformImage(t, source, "user/repo", "1.0")
+	target, targetHost := newFakeRegistry(t)
+	// A repository with the same path in the target registry must not be mounted from
+	addMultiPlatformImage(t, target, "user/repo", "1.0")
+
+	copier, warnings := copyImage(t, sourceHost+"/user/repo:1.0", targetHost+"/user/dst:1.0")
+	assert.Equal(t, copier.mounted, 0)
+	assert.Equal(t, copier.copied, 5)
+	assert.Equal(t, warnings, "")
+	assert.Equal(t, target.mounts, 0)
+
+	pushed, ok := target.get("user/dst", "1.0")
+	assert.Assert(t, ok)
+	assert.Equal(t, digest.FromBytes(pushed.data), index.Digest)
+	layer, ok := target.get("user/dst", digest.FromBytes([]byte("base layer")).String())
+	assert.Assert(t, ok)
+	assert.Equal(t, string(layer.data), "base layer")
+}
+
+func TestCpRetagsWithinARepository(t *testing.T) {
+	registry, host := newFakeRegistry(t)
+	index := addMultiPlatformImage(t, registry, "user/repo", "1.0")
+
+	copier, warnings := copyImage(t, host+"/user/repo:1.0", host+"/user/repo:latest")
+	assert.Equal(t, copier.mounted+copier.copied, 0)
+	assert.Equal(t, warnings, "")
+	assert.DeepEqual(t, registry.calls, []string{"PUT user/repo manifests"})
+
+	pushed, ok := registry.get("user/repo", "latest")
+	assert.Assert(t, ok)
+	assert.Equal(t, digest.FromBytes(pushed.data), index.Digest)
+}
@@ -0,0 +1,571 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	diffName = "diff"
+)
+
+type diffOptions struct {
+	format.Option
+	platform string
+}
+
+// Diff holds the differences between two tags
+type Diff struct {
+	From      string
+	To        string
+	Platforms *PlatformsDiff
+	Image     *ImageDiff
+}
+
+// PlatformsDiff lists the platforms added, removed or pointing to another manifest between two indexes
+type PlatformsDiff struct {
+	Added   []string
+	Removed []string
+	Changed []PlatformChange
+}
+
+// PlatformChange is a platform whose manifest changed between two indexes
+type PlatformChange struct {
+	Platform string
+	From     string
+	To       string
+}
+
+// ImageDiff holds the differences between the manifests and configs of two images
+type ImageDiff struct {
+	Platform string
+	Config   []Change
+	Layers   LayersDiff
+	History  []HistoryChange
+}
+
+// Kinds of config changes
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Change is a config field which was added, removed or modified
+type Change struct {
+	Kind  string
+	Field string
+	From  string
+	To    string
+}
+
+// LayersDiff compares the layers of two images by digest
+type LayersDiff struct {
+	Shared    []ocispec.Descriptor
+	Added     []ocispec.Descriptor
+	Removed   []ocispec.Descriptor
+	FromSize  int64
+	ToSize    int64
+	SizeDelta int64
+}
+
+// HistoryChange is a history command only present in one of the images, Op being "+" or "-"
+type HistoryChange struct {
+	Op      string
+	Command string
+}
+
+type diffTarget struct {
+	ref        string
+	name       string
+	descriptor ocispec.Descriptor
+	raw        []byte
+	index      *ocispec.Index
+}
+
+func newDiffCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts diffOptions
+	cmd := &cobra.Command{
+		Use:                   diffName + " [OPTIONS] REPOSITORY:TAG REPOSITORY:TAG",
+		Short:                 "Show the differences between two images in the registry",
+		Args:                  cli.ExactArgs(2),
+		ValidArgsFunction:     completion.Limit(2, completion.Tags(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, diffName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDiff(streams, hubClient, opts, args[0], args[1])
+		},
+	}
+	cmd.Flags().StringVar(&opts.platform, "platform", "", "Select the platform to compare if the tags are multi-architecture images")
+	opts.AddFormatFlag(cmd.Flags())
+	return cmd
+}
+
+func runDiff(streams command.Streams, hubClient *hub.Client, opts diffOptions, fromRef, toRef string) error {
+	platform := platforms.DefaultSpec()
+	if opts.platform != "" {
+		p, err := platforms.Parse(opts.platform)
+		if err != nil {
+			return errdef.Validation(fmt.Errorf("invalid platform %q: %s", opts.platform, err))
+		}
+		platform = p
+	}
+
+	ctx := hubClient.Ctx
+	resolver := newResolver(hubClient)
+	from, err := loadDiffTarget(ctx, resolver, fromRef)
+	if err != nil {
+		return err
+	}
+	to, err := loadDiffTarget(ctx, resolver, toRef)
+	if err != nil {
+		return err
+	}
+
+	diff := Diff{From: fromRef, To: toRef}
+	if from.index != nil && to.index != nil {
+		diff.Platforms = diffPlatforms(*from.index, *to.index)
+		// Without an explicit platform, only compare the images if both indexes have the default one
+		if opts.platform == "" && (selectManifest(*from.index, platform) == nil || selectManifest(*to.index, platform) == nil) {
+			fmt.Fprintln(streams.Err(), ansi.Warn(fmt.Sprintf("Skipping the image comparison as both tags don't have the %s platform, use --platform to select one", platforms.Format(platform))))
+			return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindDiff, Value: diff, Item: diffItem(diff)}, printDiff)
+		}
+	}
+
+	fromImage, err := from.image(ctx, resolver, platform)
+	if err != nil {
+		return err
+	}
+	toImage, err := to.image(ctx, resolver, platform)
+	if err != nil {
+		return err
+	}
+	diff.Image = diffImages(fromImage, toImage)
+	if from.index != nil || to.index != nil {
+		diff.Image.Platform = platforms.Format(platform)
+	}
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindDiff, Value: diff, Item: diffItem(diff)}, printDiff)
+}
+
+func loadDiffTarget(ctx context.Context, resolver remotes.Resolver, imageRef string) (*diffTarget, error) {
+	ref, descriptor, raw, err := resolveManifest(ctx, resolver, imageRef)
+	if err != nil {
+		return nil, err
+	}
+	target := &diffTarget{ref: imageRef, name: ref.Name(), descriptor: descriptor, raw: raw}
+	switch descriptor.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		var index ocispec.Index
+		if err := json.Unmarshal(raw, &index); err != nil {
+			return nil, err
+		}
+		target.index = &index
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+	default:
+		return nil, fmt.Errorf("%q: unsupported media type %q", imageRef, descriptor.MediaType)
+	}
+	return target, nil
+}
+
+func (t *diffTarget) image(ctx context.Context, resolver remotes.Resolver, platform ocispec.Platform) (*Image, error) {
+	if t.index == nil {
+		return readImage(ctx, resolver, t.raw, t.descriptor, t.name)
+	}
+	descriptor := selectManifest(*t.index, platform)
+	if descriptor == nil {
+		return nil, fmt.Errorf("platform %q does not match any available platform for the tag %q", platforms.Format(platform), t.ref)
+	}
+	raw, err := getBlob(ctx, resolver, t.name, *descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return readImage(ctx, resolver, raw, *descriptor, t.name)
+}
+
+// diffItem converts a diff to its JSON representation
+func diffItem(diff Diff) schema.Diff {
+	item := schema.Diff{From: diff.From, To: diff.To}
+	if p := diff.Platforms; p != nil {
+		changes := make([]schema.PlatformChange, 0, len(p.Changed))
+		for _, c := range p.Changed {
+			changes = append(changes, schema.PlatformChange{Platform: c.Platform, From: c.From, To: c.To})
+		}
+		item.Platforms = &schema.PlatformsDiff{
+			Added:   append([]string{}, p.Added...),
+			Removed: append([]string{}, p.Removed...),
+			Changed: changes,
+		}
+	}
+	if i := diff.Image; i != nil {
+		config := make([]schema.ConfigChange, 0, len(i.Config))
+		for _, c := range i.Config {
+			config = append(config, schema.ConfigChange{Kind: c.Kind, Field: c.Field, From: c.From, To: c.To})
+		}
+		history := make([]schema.HistoryChange, 0, len(i.History))
+		for _, h := range i.History {
+			history = append(history, schema.HistoryChange{Op: h.Op, Command: h.Command})
+		}
+		item.Image = &schema.ImageDiff{
+			Platform: i.Platform,
+			Config:   config,
+			Layers: schema.LayersDiff{
+				Shared:    layerItems(i.Layers.Shared),
+				Added:     layerItems(i.Layers.Added),
+				Removed:   layerItems(i.Layers.Removed),
+				FromSize:  i.Layers.FromSize,
+				ToSize:    i.Layers.ToSize,
+				SizeDelta: i.Layers.SizeDelta,
+			},
+			History: history,
+		}
+	}
+	return item
+}
+
+func layerItems(layers []ocispec.Descriptor) []schema.Layer {
+	result := make([]schema.Layer, 0, len(layers))
+	for _, l := range layers {
+		result = append(result, schema.Layer{MediaType: l.MediaType, Digest: l.Digest.String(), Size: l.Size})
+	}
+	return result
+}
+
+func diffPlatforms(from, to ocispec.Index) *PlatformsDiff {
+	fromDigests := indexPlatforms(from)
+	toDigests := indexPlatforms(to)
+	diff := &PlatformsDiff{}
+	for _, p := range sortMapKeys(fromDigests) {
+		toDigest, ok := toDigests[p]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, p)
+		case toDigest != fromDigests[p]:
+			diff.Changed = append(diff.Changed, PlatformChange{Platform: p, From: fromDigests[p], To: toDigest})
+		}
+	}
+	for _, p := range sortMapKeys(toDigests) {
+		if _, ok := fromDigests[p]; !ok {
+			diff.Added = append(diff.Added, p)
+		}
+	}
+	return diff
+}
+
+func indexPlatforms(index ocispec.Index) map[string]string {
+	digests := map[string]string{}
+	for _, m := range index.Manifests {
+		// Attestation manifests all share the unknown/unknown platform
+		if m.Platform == nil || isAttestationManifest(m) {
+			continue
+		}
+		digests[platforms.Format(*m.Platform)] = m.Digest.String()
+	}
+	return digests
+}
+
+func diffImages(from, to *Image) *ImageDiff {
+	return &ImageDiff{
+		Config:  diffConfigs(from.Config.Config, to.Config.Config),
+		Layers:  diffLayers(from.Manifest.Layers, to.Manifest.Layers),
+		History: diffLines(historyCommands(from.Config.History), historyCommands(to.Config.History)),
+	}
+}
+
+func diffConfigs(from, to ocispec.ImageConfig) []Change {
+	var changes []Change
+	for _, field := range []struct {
+		name     string
+		from, to []string
+	}{
+		{"Cmd", from.Cmd, to.Cmd},
+		{"Entrypoint", from.Entrypoint, to.Entrypoint},
+	} {
+		if !slices.Equal(field.from, field.to) {
+			changes = append(changes, newChange(field.name, len(field.from) > 0, len(field.to) > 0, jsonArray(field.from), jsonArray(field.to)))
+		}
+	}
+	for _, field := range []struct {
+		name     string
+		from, to string
+	}{
+		{"User", from.User, to.User},
+		{"WorkingDir", from.WorkingDir, to.WorkingDir},
+	} {
+		if field.from != field.to {
+			changes = append(changes, newChange(field.name, field.from != "", field.to != "", field.from, field.to))
+		}
+	}
+	changes = append(changes, diffMaps("Env", envMap(from.Env), envMap(to.Env))...)
+	changes = append(changes, diffMaps("Labels", from.Labels, to.Labels)...)
+	changes = append(changes, diffSets("ExposedPorts", from.ExposedPorts, to.ExposedPorts)...)
+	return changes
+}
+
+// newChange returns the change of a field, depending on whether it is set in each image
+func newChange(field string, inFrom, inTo bool, from, to string) Change {
+	switch {
+	case !inFrom:
+		return Change{Kind: ChangeAdded, Field: field, To: to}
+	case !inTo:
+		return Change{Kind: ChangeRemoved, Field: field, From: from}
+	default:
+		return Change{Kind: ChangeModified, Field: field, From: from, To: to}
+	}
+}
+
+// jsonArray formats a command like in the exec form of a Dockerfile instruction
+func jsonArray(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return strings.Join(values, " ")
+	}
+	return string(data)
+}
+
+func diffMaps(field string, from, to map[string]string) []Change {
+	keys := map[string]string{}
+	for k := range from {
+		keys[k] = k
+	}
+	for k := range to {
+		keys[k] = k
+	}
+	var changes []Change
+	for _, k := range sortMapKeys(keys) {
+		f, inFrom := from[k]
+		t, inTo := to[k]
+		if !inFrom || !inTo || f != t {
+			changes = append(changes, newChange(field+"."+k, inFrom, inTo, f, t))
+		}
+	}
+	return changes
+}
+
+func envMap(env []string) map[string]string {
+	m := map[string]string{}
+	for _, e := range env {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) == 2 {
+			m[kv[0]] = kv[1]
+		} else {
+			m[kv[0]] = ""
+		}
+	}
+	return m
+}
+
+func diffSets(field string, from, to map[string]struct{}) []Change {
+	var changes []Change
+	for _, k := range sortSetKeys(from) {
+		if _, ok := to[k]; !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Field: field, From: k})
+		}
+	}
+	for _, k := range sortSetKeys(to) {
+		if _, ok := from[k]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Field: field, To: k})
+		}
+	}
+	return changes
+}
+
+func sortSetKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func diffLayers(from, to []ocispec.Descriptor) LayersDiff {
+	diff := LayersDiff{}
+	fromDigests := map[string]bool{}
+	for _, l := range from {
+		fromDigests[l.Digest.String()] = true
+		diff.FromSize += l.Size
+	}
+	toDigests := map[string]bool{}
+	for _, l := range to {
+		toDigests[l.Digest.String()] = true
+		diff.ToSize += l.Size
+		if fromDigests[l.Digest.String()] {
+			diff.Shared = append(diff.Shared, l)
+		} else {
+			diff.Added = append(diff.Added, l)
+		}
+	}
+	for _, l := range from {
+		if !toDigests[l.Digest.String()] {
+			diff.Removed = append(diff.Removed, l)
+		}
+	}
+	diff.SizeDelta = diff.ToSize - diff.FromSize
+	return diff
+}
+
+func historyCommands(history []ocispec.History) []string {
+	var commands []string
+	for _, h := range history {
+		commands = append(commands, cleanCreatedBy(h.CreatedBy))
+	}
+	return commands
+}
+
+// diffLines returns the lines removed from a and added in b, based on their longest common subsequence
+func diffLines(a, b []string) []HistoryChange {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var changes []HistoryChange
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			changes = append(changes, HistoryChange{Op: "+", Command: b[j]})
+			j++
+		default:
+			changes = append(changes, HistoryChange{Op: "-", Command: a[i]})
+			i++
+		}
+	}
+	return changes
+}
+
+func printDiff(out io.Writer, value interface{}) error {
+	diff := value.(Diff)
+	fmt.Fprintf(out, ansi.Key("From:")+"\t%s\n", diff.From)
+	fmt.Fprintf(out, ansi.Key("To:")+"\t%s\n", diff.To)
+
+	if diff.Platforms != nil {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, ansi.Title("Platforms:"))
+		if len(diff.Platforms.Added)+len(diff.Platforms.Removed)+len(diff.Platforms.Changed) == 0 {
+			fmt.Fprintln(out, "    No difference")
+		}
+		for _, p := range diff.Platforms.Added {
+			fmt.Fprintln(out, ansi.Emphasise("    + "+p))
+		}
+		for _, p := range diff.Platforms.Removed {
+			fmt.Fprintln(out, ansi.Error("    - "+p))
+		}
+		for _, c := range diff.Platforms.Changed {
+			fmt.Fprintf(out, ansi.Warn("    ~ %s")+"\t%s -> %s\n", c.Platform, c.From, c.To)
+		}
+	}
+	if diff.Image == nil {
+		return nil
+	}
+	platform := ""
+	if diff.Image.Platform != "" {
+		platform = fmt.Sprintf(" (%s)", diff.Image.Platform)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, ansi.Title("Config"+platform+":"))
+	if len(diff.Image.Config) == 0 {
+		fmt.Fprintln(out, "    No difference")
+	}
+	for _, c := range diff.Image.Config {
+		switch c.Kind {
+		case ChangeAdded:
+			fmt.Fprintf(out, ansi.Emphasise("    + %s")+"\t%s\n", c.Field, c.To)
+		case ChangeRemoved:
+			fmt.Fprintf(out, ansi.Error("    - %s")+"\t%s\n", c.Field, c.From)
+		default:
+			fmt.Fprintf(out, ansi.Warn("    ~ %s")+"\t%s -> %s\n", c.Field, c.From, c.To)
+		}
+	}
+
+	layers := diff.Image.Layers
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, ansi.Title("Layers"+platform+":"))
+	fmt.Fprintf(out, "    %d shared, %d added, %d removed, size %s (%s -> %s)\n",
+		len(layers.Shared), len(layers.Added), len(layers.Removed), formatSizeDelta(layers.SizeDelta),
+		units.HumanSize(float64(layers.FromSize)), units.HumanSize(float64(layers.ToSize)))
+	for _, l := range layers.Added {
+		fmt.Fprintf(out, ansi.Emphasise("    + %s")+"\t%s\n", l.Digest, units.HumanSize(float64(l.Size)))
+	}
+	for _, l := range layers.Removed {
+		fmt.Fprintf(out, ansi.Error("    - %s")+"\t%s\n", l.Digest, units.HumanSize(float64(l.Size)))
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, ansi.Title("History"+platform+":"))
+	if len(diff.Image.History) == 0 {
+		fmt.Fprintln(out, "    No difference")
+	}
+	for _, h := range diff.Image.History {
+		color := ansi.Emphasise
+		if h.Op == "-" {
+			color = ansi.Error
+		}
+		fmt.Fprintln(out, color(fmt.Sprintf("    %s %s", h.Op, h.Command)))
+	}
+	return nil
+}
+
+func formatSizeDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + units.HumanSize(float64(-delta))
+	}
+	return "+" + units.HumanSize(float64(delta))
+}
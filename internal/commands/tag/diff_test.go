@@ -0,0 +1,177 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+
+	"github.com/docker/hub-tool/internal/schema"
+)
+
+func TestDiffLines(t *testing.T) {
+	changes := diffLines(
+		[]string{"ADD rootfs /", "ENV A=1", "RUN make", "CMD [\"app\"]"},
+		[]string{"ADD rootfs /", "ENV A=2", "RUN make", "USER app", "CMD [\"app\"]"},
+	)
+	assert.DeepEqual(t, changes, []HistoryChange{
+		{Op: "-", Command: "ENV A=1"},
+		{Op: "+", Command: "ENV A=2"},
+		{Op: "+", Command: "USER app"},
+	})
+}
+
+func TestDiffPlatformsIgnoresAttestations(t *testing.T) {
+	attestation := func(d, subject string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.Digest(d),
+			Platform:  &ocispec.Platform{Architecture: "unknown", OS: "unknown"},
+			Annotations: map[string]string{
+				annotationReferenceType:   referenceTypeAttestation,
+				annotationReferenceDigest: subject,
+			},
+		}
+	}
+	diff := diffPlatforms(
+		ocispec.Index{Manifests: []ocispec.Descriptor{
+			{Digest: "sha256:amd64-1", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+			{Digest: "sha256:arm64", Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64"}},
+			attestation("sha256:attestation-amd64-1", "sha256:amd64-1"),
+			attestation("sha256:attestation-arm64-1", "sha256:arm64"),
+		}},
+		ocispec.Index{Manifests: []ocispec.Descriptor{
+			{Digest: "sha256:amd64-2", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+			{Digest: "sha256:arm64", Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64"}},
+			attestation("sha256:attestation-amd64-2", "sha256:amd64-2"),
+			attestation("sha256:attestation-arm64-2", "sha256:arm64"),
+		}},
+	)
+	assert.Equal(t, len(diff.Added), 0)
+	assert.Equal(t, len(diff.Removed), 0)
+	assert.DeepEqual(t, diff.Changed, []PlatformChange{{Platform: "linux/amd64", From: "sha256:amd64-1", To: "sha256:amd64-2"}})
+}
+
+func TestDiffConfigs(t *testing.T) {
+	from := ocispec.ImageConfig{
+		Cmd:        []string{"echo", "a b"},
+		Entrypoint: []string{"/entrypoint.sh"},
+		Env:        []string{"DEBUG=", "PATH=/bin"},
+	}
+	to := ocispec.ImageConfig{
+		Cmd:  []string{"echo a", "b"},
+		User: "app",
+		Env:  []string{"PATH=/bin", "VERBOSE="},
+	}
+	assert.DeepEqual(t, diffConfigs(from, to), []Change{
+		{Kind: ChangeModified, Field: "Cmd", From: `["echo","a b"]`, To: `["echo a","b"]`},
+		{Kind: ChangeRemoved, Field: "Entrypoint", From: `["/entrypoint.sh"]`},
+		{Kind: ChangeAdded, Field: "User", To: "app"},
+		{Kind: ChangeRemoved, Field: "Env.DEBUG"},
+		{Kind: ChangeAdded, Field: "Env.VERBOSE"},
+	})
+}
+
+func TestDiffItem(t *testing.T) {
+	item := diffItem(Diff{
+		From:      "user/repo:1",
+		To:        "user/repo:2",
+		Platforms: &PlatformsDiff{Added: []string{"linux/arm64"}},
+	})
+	data, err := json.Marshal(item)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"from":"user/repo:1","to":"user/repo:2",`+
+		`"platforms":{"added":["linux/arm64"],"removed":[],"changed":[]},"image":null}`)
+
+	item = diffItem(Diff{Image: &ImageDiff{Layers: LayersDiff{
+		Shared:    []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: "sha256:base", Size: 1000}},
+		SizeDelta: -10,
+	}}})
+	assert.Assert(t, item.Platforms == nil)
+	assert.DeepEqual(t, item.Image.Layers.Shared, []schema.Layer{{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: "sha256:base", Size: 1000}})
+	assert.Equal(t, len(item.Image.Layers.Added), 0)
+	assert.Equal(t, item.Image.Layers.SizeDelta, int64(-10))
+}
+
+func TestPrintDiff(t *testing.T) {
+	from := &Image{
+		Manifest: ocispec.Manifest{Layers: []ocispec.Descriptor{
+			{Digest: "sha256:base", Size: 1000},
+			{Digest: "sha256:app1", Size: 500},
+		}},
+		Config: ocispec.Image{
+			Config: ocispec.ImageConfig{
+				User:         "root",
+				Env:          []string{"PATH=/bin", "VERSION=1.0"},
+				Cmd:          []string{"app"},
+				ExposedPorts: map[string]struct{}{"80/tcp": {}},
+				Labels:       map[string]string{"maintainer": "me", "old": "label"},
+			},
+			History: []ocispec.History{
+				{CreatedBy: "/bin/sh -c #(nop) ADD file:abc in / "},
+				{CreatedBy: "/bin/sh -c make", EmptyLayer: false},
+			},
+		},
+	}
+	to := &Image{
+		Manifest: ocispec.Manifest{Layers: []ocispec.Descriptor{
+			{Digest: "sha256:base", Size: 1000},
+			{Digest: "sha256:app2", Size: 2000},
+		}},
+		Config: ocispec.Image{
+			Config: ocispec.ImageConfig{
+				User:         "app",
+				Env:          []string{"PATH=/bin", "VERSION=1.1"},
+				Cmd:          []string{"app"},
+				ExposedPorts: map[string]struct{}{"80/tcp": {}, "443/tcp": {}},
+				Labels:       map[string]string{"maintainer": "me"},
+			},
+			History: []ocispec.History{
+				{CreatedBy: "/bin/sh -c #(nop) ADD file:abc in / "},
+				{CreatedBy: "/bin/sh -c make release", EmptyLayer: false},
+				{CreatedBy: "/bin/sh -c #(nop) USER app", EmptyLayer: true},
+			},
+		},
+	}
+	diff := Diff{
+		From: "user/repo:rc1",
+		To:   "user/repo:rc2",
+		Platforms: diffPlatforms(
+			ocispec.Index{Manifests: []ocispec.Descriptor{
+				{Digest: "sha256:amd64-1", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+				{Digest: "sha256:s390x", Platform: &ocispec.Platform{OS: "linux", Architecture: "s390x"}},
+			}},
+			ocispec.Index{Manifests: []ocispec.Descriptor{
+				{Digest: "sha256:amd64-2", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+				{Digest: "sha256:arm64", Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+			}},
+		),
+		Image: diffImages(from, to),
+	}
+	diff.Image.Platform = "linux/amd64"
+
+	buf := bytes.NewBuffer(nil)
+	err := printDiff(buf, diff)
+	assert.NilError(t, err)
+	golden.Assert(t, buf.String(), "diff.golden")
+}
@@ -0,0 +1,202 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+var tagFilterKeys = []string{"name", "status", "pushed", "pulled", "updated", "size", "platform"}
+
+// tagFilter selects tags client side. The literal part of a name glob is
+// also sent to Hub so fewer pages need to be fetched.
+type tagFilter struct {
+	name       string
+	predicates []func(hub.Tag) bool
+}
+
+func parseTagFilters(exprs []string, now time.Time) (*tagFilter, error) {
+	filters, err := filter.ParseAll(exprs, tagFilterKeys...)
+	if err != nil {
+		return nil, errdef.Validation(err)
+	}
+	tf := &tagFilter{}
+	for _, f := range filters {
+		predicate, err := tagPredicate(f, now)
+		if err != nil {
+			return nil, errdef.Validation(err)
+		}
+		if f.Key == "name" && f.Operator == filter.Equal && tf.name == "" {
+			tf.name = globLiteral(f.Value)
+		}
+		tf.predicates = append(tf.predicates, predicate)
+	}
+	return tf, nil
+}
+
+func tagPredicate(f filter.Filter, now time.Time) (func(hub.Tag) bool, error) {
+	switch f.Key {
+	case "name":
+		match, err := f.StringMatcher()
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Tag) bool { return match(shortTagName(t)) }, nil
+	case "status":
+		match, err := f.StringMatcher()
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Tag) bool { return match(t.Status) }, nil
+	case "pushed", "pulled", "updated":
+		match, err := f.TimeMatcher(now)
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Tag) bool {
+			switch f.Key {
+			case "pushed":
+				return match(t.LastPushed)
+			case "pulled":
+				return match(t.LastPulled)
+			default:
+				return match(t.LastUpdated)
+			}
+		}, nil
+	case "size":
+		match, err := f.SizeMatcher()
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Tag) bool { return match(int64(tagSize(t))) }, nil
+	case "platform":
+		return platformPredicate(f)
+	default:
+		return nil, fmt.Errorf("invalid filter %q: unknown key %q", f, f.Key)
+	}
+}
+
+func platformPredicate(f filter.Filter) (func(hub.Tag) bool, error) {
+	if f.Operator != filter.Equal && f.Operator != filter.NotEqual {
+		return nil, fmt.Errorf("invalid filter %q: operator %q is not supported for %q", f, f.Operator, f.Key)
+	}
+	p, err := platforms.Parse(f.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %s", f, err)
+	}
+	matcher := platforms.NewMatcher(p)
+	negate := f.Operator == filter.NotEqual
+	return func(t hub.Tag) bool {
+		found := false
+		for _, image := range t.Images {
+			if matcher.Match(ocispec.Platform{OS: image.Os, Architecture: image.Architecture, Variant: image.Variant}) {
+				found = true
+				break
+			}
+		}
+		return found != negate
+	}, nil
+}
+
+// local returns true if some tags need to be filtered out after being fetched
+func (f *tagFilter) local() bool {
+	return len(f.predicates) > 0
+}
+
+func (f *tagFilter) requestOps() []hub.RequestOp {
+	if f.name == "" {
+		return nil
+	}
+	return []hub.RequestOp{hub.WithNameFilter(f.name)}
+}
+
+func (f *tagFilter) apply(tags []hub.Tag) []hub.Tag {
+	var result []hub.Tag
+	for _, t := range tags {
+		if f.match(t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (f *tagFilter) match(t hub.Tag) bool {
+	for _, predicate := range f.predicates {
+		if !predicate(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// globLiteral returns the longest part of a glob pattern without any special character,
+// bracket expressions are skipped as a whole since they match a single unknown character
+func globLiteral(pattern string) string {
+	longest, part := "", ""
+	keep := func() {
+		if len(part) > len(longest) {
+			longest = part
+		}
+		part = ""
+	}
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*', '?':
+			keep()
+		case '\\':
+			if i++; i < len(runes) {
+				part += string(runes[i])
+			}
+		case '[':
+			keep()
+			for i++; i < len(runes) && runes[i] != ']'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+		default:
+			part += string(runes[i])
+		}
+	}
+	keep()
+	return longest
+}
+
+func shortTagName(t hub.Tag) string {
+	return t.Name[strings.LastIndex(t.Name, ":")+1:]
+}
+
+func tagSize(t hub.Tag) int {
+	if len(t.Images) == 0 {
+		return t.FullSize
+	}
+	size := 0
+	for _, image := range t.Images {
+		size += image.Size
+	}
+	return size
+}
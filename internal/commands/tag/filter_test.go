@@ -0,0 +1,124 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func TestTagFilters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tags := []hub.Tag{
+		{
+			Name:       "user/repo:1.0",
+			Status:     "inactive",
+			LastPulled: now.Add(-90 * 24 * time.Hour),
+			Images:     []hub.Image{{Os: "linux", Architecture: "amd64", Size: 50 * 1000 * 1000}},
+		},
+		{
+			Name:       "user/repo:1.1",
+			Status:     "active",
+			LastPulled: now.Add(-time.Hour),
+			Images: []hub.Image{
+				{Os: "linux", Architecture: "amd64", Size: 100 * 1000 * 1000},
+				{Os: "linux", Architecture: "arm64", Variant: "v8", Size: 100 * 1000 * 1000},
+			},
+		},
+		{
+			Name:   "user/repo:latest",
+			Status: "active",
+			Images: []hub.Image{{Os: "linux", Architecture: "arm64", Size: 10}},
+		},
+	}
+	testCases := []struct {
+		name     string
+		filters  []string
+		expected []string
+	}{
+		{name: "no filter", expected: []string{"user/repo:1.0", "user/repo:1.1", "user/repo:latest"}},
+		{name: "name glob", filters: []string{"name=1.*"}, expected: []string{"user/repo:1.0", "user/repo:1.1"}},
+		{name: "name bracket", filters: []string{"name=[0-9]*"}, expected: []string{"user/repo:1.0", "user/repo:1.1"}},
+		{name: "name bracket range", filters: []string{"name=1.[1-9]"}, expected: []string{"user/repo:1.1"}},
+		{name: "name regex", filters: []string{`name~^\d+\.1$`}, expected: []string{"user/repo:1.1"}},
+		{name: "status", filters: []string{"status=inactive"}, expected: []string{"user/repo:1.0"}},
+		{name: "never pulled or stale", filters: []string{"pulled<30d"}, expected: []string{"user/repo:1.0", "user/repo:latest"}},
+		{name: "size", filters: []string{"size>100MB"}, expected: []string{"user/repo:1.1"}},
+		{name: "platform", filters: []string{"platform=linux/arm64"}, expected: []string{"user/repo:1.1", "user/repo:latest"}},
+		{name: "missing platform", filters: []string{"platform!=linux/arm64"}, expected: []string{"user/repo:1.0"}},
+		{name: "combined", filters: []string{"name=1.*", "status=active"}, expected: []string{"user/repo:1.1"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			f, err := parseTagFilters(testCase.filters, now)
+			assert.NilError(t, err)
+			var names []string
+			for _, tag := range f.apply(tags) {
+				names = append(names, tag.Name)
+			}
+			assert.DeepEqual(t, names, testCase.expected)
+		})
+	}
+}
+
+func TestGlobLiteral(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "1.*", expected: "1."},
+		{pattern: "*-alpine*", expected: "-alpine"},
+		{pattern: "[0-9]*", expected: ""},
+		{pattern: "v[12].0", expected: ".0"},
+		{pattern: "[^a-z]-alpine", expected: "-alpine"},
+		{pattern: `[\]]latest`, expected: "latest"},
+		{pattern: `1\*2`, expected: "1*2"},
+		{pattern: "?", expected: ""},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, globLiteral(testCase.pattern), testCase.expected, testCase.pattern)
+	}
+}
+
+func TestTagFiltersErrors(t *testing.T) {
+	_, err := parseTagFilters([]string{"digest=sha256:abc"}, time.Now())
+	assert.ErrorContains(t, err, `unknown key "digest"`)
+	_, err = parseTagFilters([]string{"platform<linux/amd64"}, time.Now())
+	assert.Error(t, err, `invalid filter "platform<linux/amd64": operator "<" is not supported for "platform"`)
+	_, err = parseTagFilters([]string{"pushed=yesterday"}, time.Now())
+	assert.Error(t, err, `invalid filter "pushed=yesterday": "yesterday" is neither a date nor a duration`)
+}
+
+func TestNameFilterSentToHub(t *testing.T) {
+	f, err := parseTagFilters([]string{"name=*-alpine*"}, time.Now())
+	assert.NilError(t, err)
+	assert.Equal(t, f.name, "-alpine")
+	assert.Equal(t, len(f.requestOps()), 1)
+
+	f, err = parseTagFilters([]string{"name=[0-9]*"}, time.Now())
+	assert.NilError(t, err)
+	assert.Equal(t, f.name, "")
+	assert.Equal(t, len(f.requestOps()), 0)
+
+	f, err = parseTagFilters([]string{"name~alpine"}, time.Now())
+	assert.NilError(t, err)
+	assert.Equal(t, len(f.requestOps()), 0)
+}
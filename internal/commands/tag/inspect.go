@@ -35,10 +35,14 @@ import (
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/go-units"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/metrics"
 	"github.com/docker/hub-tool/pkg/hub"
 )
@@ -48,8 +52,9 @@ const (
 )
 
 type inspectOptions struct {
-	format   string
-	platform string
+	format       string
+	platform     string
+	attestations bool
 }
 
 // Image is the combination of a manifest and its config object
@@ -73,6 +78,7 @@ func newInspectCmd(streams command.Streams, hubClient *hub.Client, parent string
 		Use:                   inspectName + " [OPTIONS] REPOSITORY:TAG",
 		Short:                 "Show the details of an image in the registry",
 		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Tags(hubClient)),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, inspectName)
@@ -81,8 +87,9 @@ func newInspectCmd(streams command.Streams, hubClient *hub.Client, parent string
 			return runInspect(streams, hubClient, opts, args[0])
 		},
 	}
-	cmd.Flags().StringVar(&opts.format, "format", "", `Print original manifest ("json|raw")`)
+	cmd.Flags().StringVar(&opts.format, "format", "", `Print original manifest ("json|raw") or using a Go template (e.g.: "{{.Descriptor.Digest}}")`)
 	cmd.Flags().StringVar(&opts.platform, "platform", "", `Select a platform if the tag is a multi-architecture image`)
+	cmd.Flags().BoolVar(&opts.attestations, "attestations", false, "Show the attestations, signatures and SBOMs attached to the image")
 	return cmd
 }
 
@@ -93,50 +100,69 @@ func runInspect(streams command.Streams, hubClient *hub.Client, opts inspectOpti
 	if opts.platform != "" {
 		p, err := platforms.Parse(opts.platform)
 		if err != nil {
-			return fmt.Errorf("invalid platform %q: %s", opts.platform, err)
+			return errdef.Validation(fmt.Errorf("invalid platform %q: %s", opts.platform, err))
 		}
 		platform = &p
 	}
+	hosts := newRegistryHosts(hubClient)
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: hosts,
+	})
+	ref, descriptor, raw, err := resolveManifest(hubClient.Ctx, resolver, imageRef)
+	if err != nil {
+		return err
+	}
+	if opts.attestations {
+		return formatAttestations(hubClient.Ctx, streams, hosts, resolver, opts.format, ref, descriptor, raw, platform)
+	}
+
+	switch descriptor.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		return formatManifestlist(hubClient.Ctx, streams, resolver, opts.format, raw, descriptor, ref.Name(), platform)
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		return formatManifest(hubClient.Ctx, streams, resolver, opts.format, raw, descriptor, ref.Name())
+	case images.MediaTypeDockerSchema1Manifest, mediaTypeDockerSchema1Unsigned:
+		return formatSchema1(streams, opts.format, raw, descriptor, ref.Name())
+	case mediaTypeArtifactManifest:
+		return formatArtifact(streams, opts.format, raw, descriptor, ref.Name())
+	default:
+		return formatDescriptor(streams, opts.format, raw, descriptor, ref.Name())
+	}
+}
+
+func newRegistryHosts(hubClient *hub.Client) docker.RegistryHosts {
 	authorizer := docker.NewDockerAuthorizer(docker.WithAuthCreds(func(string) (string, string, error) {
 		return hubClient.AuthConfig.Username, hubClient.AuthConfig.Password, nil
 	}))
-	registryHosts := docker.ConfigureDefaultRegistries(docker.WithClient(http.DefaultClient), docker.WithAuthorizer(authorizer))
+	return docker.ConfigureDefaultRegistries(docker.WithClient(http.DefaultClient), docker.WithAuthorizer(authorizer))
+}
 
-	resolver := docker.NewResolver(docker.ResolverOptions{
-		Hosts: registryHosts,
+func newResolver(hubClient *hub.Client) remotes.Resolver {
+	return docker.NewResolver(docker.ResolverOptions{
+		Hosts: newRegistryHosts(hubClient),
 	})
+}
 
+// resolveManifest returns the normalized reference, the descriptor and the content of the manifest or index of an image
+func resolveManifest(ctx context.Context, resolver remotes.Resolver, imageRef string) (reference.Named, ocispec.Descriptor, []byte, error) {
 	// Parse image reference
 	ref, err := reference.ParseNormalizedNamed(imageRef)
 	if err != nil {
-		return err
+		return nil, ocispec.Descriptor{}, nil, err
 	}
 	ref = reference.TagNameOnly(ref)
 
 	// Read descriptor
-	fullName, descriptor, err := resolver.Resolve(hubClient.Ctx, ref.String())
+	fullName, descriptor, err := resolver.Resolve(ctx, ref.String())
 	if err != nil {
-		return err
+		return nil, ocispec.Descriptor{}, nil, err
 	}
 
-	raw, err := getBlob(hubClient.Ctx, resolver, fullName, descriptor)
+	raw, err := getBlob(ctx, resolver, fullName, descriptor)
 	if err != nil {
-		return err
+		return nil, ocispec.Descriptor{}, nil, err
 	}
-
-	switch descriptor.MediaType {
-	// case images.MediaTypeDockerSchema2Manifest, specs.MediaTypeImageManifest:
-	// TODO: handle distribution manifest and schema1
-	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
-		return formatManifestlist(hubClient.Ctx, streams, resolver, opts.format, raw, descriptor, ref.Name(), platform)
-	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
-		return formatManifest(hubClient.Ctx, streams, resolver, opts.format, raw, descriptor, ref.Name())
-	default:
-		fmt.Fprintln(streams.Out(), ansi.Title("Unsupported mediatype"))
-		fmt.Fprintln(streams.Out(), raw)
-	}
-
-	return nil
+	return ref, descriptor, raw, nil
 }
 
 func getBlob(ctx context.Context, resolver remotes.Resolver, fullName string, descriptor ocispec.Descriptor) ([]byte, error) {
@@ -192,12 +218,19 @@ func formatManifestlist(ctx context.Context, streams command.Streams, resolver r
 	case "":
 		return printManifestList(streams.Out(), image)
 	default:
-		return fmt.Errorf("unsupported format type: %q", format)
+		return printTemplate(streams.Out(), format, image)
 	}
 }
 
 func formatManifest(ctx context.Context, streams command.Streams, resolver remotes.Resolver,
 	format string, raw []byte, descriptor ocispec.Descriptor, name string) error {
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return err
+	}
+	if isArtifact(manifest) {
+		return formatArtifact(streams, format, raw, descriptor, name)
+	}
 	image, err := readImage(ctx, resolver, raw, descriptor, name)
 	if err != nil {
 		return err
@@ -216,20 +249,13 @@ func formatManifest(ctx context.Context, streams command.Streams, resolver remot
 	case "":
 		return printImage(streams.Out(), image)
 	default:
-		return fmt.Errorf("unsupported format type: %q", format)
+		return printTemplate(streams.Out(), format, image)
 	}
 }
 
 func formatSelectManifest(ctx context.Context, streams command.Streams, resolver remotes.Resolver,
 	format string, name string, platform ocispec.Platform, index ocispec.Index) error {
-	matcher := platforms.NewMatcher(platform)
-	var selectedDescriptor *ocispec.Descriptor
-	for _, descriptor := range index.Manifests {
-		if descriptor.Platform != nil && matcher.Match(*descriptor.Platform) {
-			selectedDescriptor = &descriptor
-			break
-		}
-	}
+	selectedDescriptor := selectManifest(index, platform)
 	if selectedDescriptor == nil {
 		return fmt.Errorf("platform %q does not match any available platform for the tag %q", platforms.Format(platform), name)
 	}
@@ -240,6 +266,17 @@ func formatSelectManifest(ctx context.Context, streams command.Streams, resolver
 	return formatManifest(ctx, streams, resolver, format, raw, *selectedDescriptor, name)
 }
 
+// selectManifest returns the descriptor of the manifest matching the platform, if any
+func selectManifest(index ocispec.Index, platform ocispec.Platform) *ocispec.Descriptor {
+	matcher := platforms.NewMatcher(platform)
+	for _, descriptor := range index.Manifests {
+		if descriptor.Platform != nil && matcher.Match(*descriptor.Platform) {
+			return &descriptor
+		}
+	}
+	return nil
+}
+
 func readImage(ctx context.Context, resolver remotes.Resolver, rawManifest []byte, descriptor ocispec.Descriptor, name string) (*Image, error) {
 	var manifest ocispec.Manifest
 	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
@@ -283,14 +320,29 @@ func printManifestList(out io.Writer, image Index) error {
 
 	fmt.Fprintf(out, "\n")
 
+	// Attestation manifests are listed with the image they describe
+	attestations := attestationsBySubject(image.Index)
+	subjects := map[digest.Digest]bool{}
+	for _, m := range image.Index.Manifests {
+		subjects[m.Digest] = true
+	}
+
 	fmt.Fprintf(out, ansi.Title("Manifests:")+"\n")
-	for i, m := range image.Index.Manifests {
-		if i != 0 {
+	first := true
+	for _, m := range image.Index.Manifests {
+		if isAttestationManifest(m) && subjects[digest.Digest(m.Annotations[annotationReferenceDigest])] {
+			continue
+		}
+		if !first {
 			fmt.Fprintln(out)
 		}
+		first = false
 		fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s\n", fmt.Sprintf("%s@%s", image.Name, m.Digest))
 		fmt.Fprintf(out, ansi.Key("Mediatype:")+"\t%s\n", m.MediaType)
 		fmt.Fprintf(out, ansi.Key("Platform:")+"\t%s\n", formatPlatform(m.Platform))
+		for _, a := range attestations[m.Digest] {
+			fmt.Fprintf(out, ansi.Key("Attestations:")+"\t%s\n", fmt.Sprintf("%s@%s", image.Name, a.Digest))
+		}
 	}
 
 	return nil
@@ -329,46 +381,50 @@ func printConfig(out io.Writer, image *Image) error {
 	fmt.Fprintf(out, ansi.Key("MediaType:")+"\t%s\n", image.Manifest.Config.MediaType)
 	fmt.Fprintf(out, ansi.Key("Size:")+"\t\t%v\n", units.HumanSize(float64(image.Manifest.Config.Size)))
 	fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s\n", image.Manifest.Config.Digest)
-	if len(image.Config.Config.Cmd) > 0 {
-		fmt.Fprintf(out, ansi.Key("Command:")+"\t%q\n", strings.TrimPrefix(strings.Join(image.Config.Config.Cmd, " "), "/bin/sh -c "))
+	printImageConfig(out, image.Config.Config)
+	return nil
+}
+
+func printImageConfig(out io.Writer, config ocispec.ImageConfig) {
+	if len(config.Cmd) > 0 {
+		fmt.Fprintf(out, ansi.Key("Command:")+"\t%q\n", strings.TrimPrefix(strings.Join(config.Cmd, " "), "/bin/sh -c "))
 	}
-	if len(image.Config.Config.Entrypoint) > 0 {
-		fmt.Fprintf(out, ansi.Key("Entrypoint:")+"\t%q\n", strings.Join(image.Config.Config.Entrypoint, " "))
+	if len(config.Entrypoint) > 0 {
+		fmt.Fprintf(out, ansi.Key("Entrypoint:")+"\t%q\n", strings.Join(config.Entrypoint, " "))
 	}
-	if image.Config.Config.User != "" {
-		fmt.Fprintf(out, ansi.Key("User:")+"\t%s\n", image.Config.Config.User)
+	if config.User != "" {
+		fmt.Fprintf(out, ansi.Key("User:")+"\t%s\n", config.User)
 	}
-	if len(image.Config.Config.ExposedPorts) > 0 {
-		fmt.Fprintf(out, ansi.Key("Exposed ports:")+"\t%s\n", getExposedPorts(image.Config.Config.ExposedPorts))
+	if len(config.ExposedPorts) > 0 {
+		fmt.Fprintf(out, ansi.Key("Exposed ports:")+"\t%s\n", getExposedPorts(config.ExposedPorts))
 	}
-	if len(image.Config.Config.Env) > 0 {
+	if len(config.Env) > 0 {
 		fmt.Fprintf(out, ansi.Key("Environment:")+"\n")
-		for _, env := range image.Config.Config.Env {
+		for _, env := range config.Env {
 			fmt.Fprintf(out, "    %s\n", env)
 		}
 	}
-	if len(image.Config.Config.Volumes) > 0 {
+	if len(config.Volumes) > 0 {
 		fmt.Fprintf(out, ansi.Key("Volumes:")+"\n")
-		for volume := range image.Config.Config.Volumes {
+		for volume := range config.Volumes {
 			fmt.Fprintf(out, "%s\n", volume)
 		}
 	}
-	if image.Config.Config.WorkingDir != "" {
-		fmt.Fprintf(out, ansi.Key("Working Directory:")+"\t%q\n", image.Config.Config.WorkingDir)
+	if config.WorkingDir != "" {
+		fmt.Fprintf(out, ansi.Key("Working Directory:")+"\t%q\n", config.WorkingDir)
 	}
-	if len(image.Config.Config.Labels) > 0 {
+	if len(config.Labels) > 0 {
 		fmt.Fprintf(out, ansi.Key("Labels:")+"\n")
-		keys := sortMapKeys(image.Config.Config.Labels)
+		keys := sortMapKeys(config.Labels)
 		for _, k := range keys {
-			fmt.Fprintf(out, "    %s=%q\n", k, image.Config.Config.Labels[k])
+			fmt.Fprintf(out, "    %s=%q\n", k, config.Labels[k])
 		}
 	}
-	if image.Config.Config.StopSignal != "" {
-		fmt.Fprintf(out, ansi.Key("Stop signal:")+"\t\t%s\n", image.Config.Config.StopSignal)
+	if config.StopSignal != "" {
+		fmt.Fprintf(out, ansi.Key("Stop signal:")+"\t\t%s\n", config.StopSignal)
 	}
 
 	fmt.Fprintf(out, "\n")
-	return nil
 }
 
 func printLayers(out io.Writer, image *Image) error {
@@ -391,6 +447,14 @@ func printLayers(out io.Writer, image *Image) error {
 	return nil
 }
 
+// printTemplate prints the value using a Go template, the only format left
+func printTemplate(out io.Writer, tmpl string, value interface{}) error {
+	if !format.IsTemplate(tmpl) {
+		return fmt.Errorf("unsupported format type: %q", tmpl)
+	}
+	return format.Template(out, tmpl, value)
+}
+
 func formatPlatform(platform *ocispec.Platform) string {
 	if platform == nil {
 		return ""
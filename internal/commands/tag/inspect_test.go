@@ -18,6 +18,7 @@ package tag
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -140,3 +141,168 @@ func TestPrintIndex(t *testing.T) {
 	assert.NilError(t, err)
 	golden.Assert(t, out.String(), "inspect-manifest-list.golden")
 }
+
+func TestReadSchema1(t *testing.T) {
+	raw := []byte(`{
+   "schemaVersion": 1,
+   "name": "user/legacy",
+   "tag": "1.0",
+   "architecture": "amd64",
+   "fsLayers": [
+      {"blobSum": "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4"},
+      {"blobSum": "sha256:top"},
+      {"blobSum": "sha256:base"}
+   ],
+   "history": [
+      {"v1Compatibility": "{\"os\":\"linux\",\"config\":{\"Cmd\":[\"/app\"],\"Env\":[\"A=1\"]},\"container_config\":{\"Cmd\":[\"/bin/sh\",\"-c\",\"#(nop) CMD [\\\"/app\\\"]\"]},\"throwaway\":true}"},
+      {"v1Compatibility": "{\"container_config\":{\"Cmd\":[\"/bin/sh\",\"-c\",\"make\"]},\"Size\":2048}"},
+      {"v1Compatibility": "{\"container_config\":{\"Cmd\":[\"/bin/sh\",\"-c\",\"#(nop) ADD file:abc in /\"]},\"Size\":1024}"}
+   ],
+   "signatures": [
+      {"header": {"jwk": {"kid": "ABCD:EFGH", "kty": "EC"}, "alg": "ES256"}, "signature": "sig", "protected": "prot"}
+   ]
+}`)
+	descriptor := ocispec.Descriptor{
+		MediaType: "application/vnd.docker.distribution.manifest.v1+prettyjws",
+		Digest:    "sha256:abcdef",
+	}
+	image, err := readSchema1(raw, descriptor, "user/legacy")
+	assert.NilError(t, err)
+	assert.Equal(t, image.Config.OS, "linux")
+	assert.Equal(t, image.Config.Architecture, "amd64")
+	assert.DeepEqual(t, image.Config.Config.Cmd, []string{"/app"})
+	assert.Equal(t, len(image.Manifest.Layers), 2)
+	assert.Equal(t, image.Manifest.Layers[0].Digest.String(), "sha256:base")
+	assert.Equal(t, image.Manifest.Layers[1].Size, int64(2048))
+	assert.Equal(t, len(image.Config.History), 3)
+	assert.Assert(t, image.Config.History[2].EmptyLayer)
+	assert.DeepEqual(t, image.Signatures, []Schema1Signature{{KeyID: "ABCD:EFGH", Algorithm: "ES256"}})
+
+	out := bytes.NewBuffer(nil)
+	err = printSchema1(out, image)
+	assert.NilError(t, err)
+	golden.Assert(t, out.String(), "inspect-schema1.golden")
+}
+
+func TestPrintArtifact(t *testing.T) {
+	raw := []byte(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/vnd.example.sbom.v1",
+  "config": {"mediaType": "application/vnd.oci.empty.v1+json", "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", "size": 2},
+  "layers": [
+    {"mediaType": "application/spdx+json", "digest": "sha256:beef", "size": 1234, "annotations": {"org.opencontainers.image.title": "sbom.spdx.json"}}
+  ],
+  "subject": {"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": "sha256:c0ffee", "size": 500}
+}`)
+	descriptor := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:abcdef",
+	}
+	var manifest ocispec.Manifest
+	assert.NilError(t, json.Unmarshal(raw, &manifest))
+	assert.Assert(t, isArtifact(manifest))
+
+	artifact, err := readArtifact(raw, descriptor, "user/repo")
+	assert.NilError(t, err)
+	out := bytes.NewBuffer(nil)
+	err = printArtifact(out, artifact)
+	assert.NilError(t, err)
+	golden.Assert(t, out.String(), "inspect-artifact.golden")
+}
+
+func TestPrintIndexWithAttestations(t *testing.T) {
+	index := ocispec.Index{
+		Manifests: []ocispec.Descriptor{
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    "sha256:amd64",
+				Platform:  &ocispec.Platform{Architecture: "amd64", OS: "linux"},
+			},
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    "sha256:arm64",
+				Platform:  &ocispec.Platform{Architecture: "arm64", OS: "linux"},
+			},
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    "sha256:attestation-amd64",
+				Platform:  &ocispec.Platform{Architecture: "unknown", OS: "unknown"},
+				Annotations: map[string]string{
+					annotationReferenceType:   referenceTypeAttestation,
+					annotationReferenceDigest: "sha256:amd64",
+				},
+			},
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    "sha256:orphan",
+				Platform:  &ocispec.Platform{Architecture: "unknown", OS: "unknown"},
+				Annotations: map[string]string{
+					annotationReferenceType:   referenceTypeAttestation,
+					annotationReferenceDigest: "sha256:missing",
+				},
+			},
+		},
+	}
+	indexDescriptor := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    "sha256:index",
+	}
+
+	out := bytes.NewBuffer(nil)
+	err := printManifestList(out, Index{"image:latest", index, indexDescriptor})
+	assert.NilError(t, err)
+	golden.Assert(t, out.String(), "inspect-manifest-list-attestations.golden")
+}
+
+func TestPrintAttestations(t *testing.T) {
+	provenance, err := readStatement([]byte(`{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "subject": [{"name": "pkg:docker/user/repo@latest?platform=linux%2Famd64", "digest": {"sha256": "amd64"}}],
+  "predicate": {"builder": {"id": "https://github.com/user/repo/actions/runs/1"}, "buildType": "https://mobyproject.org/buildkit@v1"}
+}`))
+	assert.NilError(t, err)
+	assert.Equal(t, provenance.Builder(), "https://github.com/user/repo/actions/runs/1")
+
+	sbom, err := readStatement([]byte(`{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://spdx.dev/Document",
+  "subject": [{"name": "pkg:docker/user/repo@latest?platform=linux%2Famd64", "digest": {"sha256": "amd64"}}],
+  "predicate": {"spdxVersion": "SPDX-2.3"}
+}`))
+	assert.NilError(t, err)
+	assert.Equal(t, sbom.Builder(), "")
+
+	v1, err := readStatement([]byte(`{"predicateType": "https://slsa.dev/provenance/v1", "predicate": {"runDetails": {"builder": {"id": "builder-v1"}}}}`))
+	assert.NilError(t, err)
+	assert.Equal(t, v1.Builder(), "builder-v1")
+
+	_, err = readStatement([]byte(`{"_type": "https://in-toto.io/Statement/v0.1"}`))
+	assert.ErrorContains(t, err, "missing predicate type")
+
+	attestations := &Attestations{
+		Name:   "docker.io/user/repo",
+		Digest: "sha256:index",
+		Images: []ImageAttestations{
+			{
+				Platform:   &ocispec.Platform{Architecture: "amd64", OS: "linux"},
+				Digest:     "sha256:amd64",
+				Manifest:   "sha256:attestation-amd64",
+				Statements: []Statement{provenance, sbom},
+			},
+		},
+		Referrers: []ocispec.Descriptor{
+			{
+				MediaType:    ocispec.MediaTypeImageManifest,
+				ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json",
+				Digest:       "sha256:signature",
+				Annotations:  map[string]string{"org.opencontainers.image.created": "2023-01-01T00:00:00Z"},
+			},
+		},
+	}
+	out := bytes.NewBuffer(nil)
+	err = printAttestations(out, attestations)
+	assert.NilError(t, err)
+	golden.Assert(t, out.String(), "inspect-attestations.golden")
+}
@@ -0,0 +1,193 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/internal/semver"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	latestName = "latest"
+)
+
+type latestOptions struct {
+	format.Option
+	constraint  string
+	variant     string
+	allVariants bool
+	prerelease  bool
+}
+
+// latestVersion is the newest tag of a variant, like "alpine" for "1.4.2-alpine"
+type latestVersion struct {
+	Variant string
+	Version string
+	Tag     hub.Tag
+}
+
+func newLatestCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts latestOptions
+	cmd := &cobra.Command{
+		Use:                   latestName + " [OPTIONS] REPOSITORY",
+		Short:                 "Show the newest tag of a repository according to semantic versioning",
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Repositories(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, latestName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runLatest(streams, hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&opts.constraint, "constraint", "", `Only consider versions matching a constraint (e.g.: "^1.4", "~1.4.2", ">=1.2 <2")`)
+	cmd.Flags().StringVar(&opts.variant, "variant", "", `Select the tag variant, the suffix after the version (e.g.: "alpine" for "1.4.2-alpine")`)
+	cmd.Flags().BoolVar(&opts.allVariants, "all-variants", false, "Show the newest tag of every variant")
+	cmd.Flags().BoolVar(&opts.prerelease, "prerelease", false, "Consider pre-release versions (e.g.: 2.0.0-rc1)")
+	opts.AddFormatFlag(cmd.Flags())
+	return cmd
+}
+
+func runLatest(streams command.Streams, hubClient *hub.Client, opts latestOptions, repository string) error {
+	var constraint *semver.Constraint
+	if opts.constraint != "" {
+		c, err := semver.ParseConstraint(opts.constraint)
+		if err != nil {
+			return errdef.Validation(err)
+		}
+		constraint = c
+	}
+	if err := hubClient.Update(hub.WithAllElements()); err != nil {
+		return err
+	}
+	tags, _, err := hubClient.GetTags(repository)
+	if err != nil {
+		return err
+	}
+
+	latest := latestVersions(tags, constraint, opts.prerelease)
+	if opts.allVariants {
+		if len(latest) == 0 {
+			return fmt.Errorf("no tag of %q matches %s", repository, describeConstraint(constraint))
+		}
+		return opts.PrintList(streams.Out(), format.List{Kind: schema.KindLatestVersion, Total: len(latest), Values: latest, Items: latestVersionItems(latest)}, printLatestVersions, nil)
+	}
+	for _, l := range latest {
+		if l.Variant == opts.variant {
+			return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindLatestVersion, Value: l, Item: latestVersionItem(l)}, printLatestVersion)
+		}
+	}
+	if opts.variant != "" {
+		return fmt.Errorf("no tag of %q with variant %q matches %s", repository, opts.variant, describeConstraint(constraint))
+	}
+	return fmt.Errorf("no tag of %q matches %s", repository, describeConstraint(constraint))
+}
+
+// latestVersions returns the newest version of each variant, sorted by variant
+func latestVersions(tags []hub.Tag, constraint *semver.Constraint, prerelease bool) []latestVersion {
+	newest := map[string]*semver.Version{}
+	byVariant := map[string]hub.Tag{}
+	for _, t := range tags {
+		v, err := semver.Parse(shortTagName(t))
+		if err != nil {
+			continue
+		}
+		if v.Prerelease != "" && !prerelease {
+			continue
+		}
+		if constraint != nil && !constraint.Check(v) {
+			continue
+		}
+		// On equal versions, prefer the most specific tag (1.4.0 over 1.4)
+		if n, ok := newest[v.Variant]; ok && (v.LessThan(n) || (v.Compare(n) == 0 && len(v.Original) <= len(n.Original))) {
+			continue
+		}
+		newest[v.Variant] = v
+		byVariant[v.Variant] = t
+	}
+
+	var result []latestVersion
+	for variant, v := range newest {
+		result = append(result, latestVersion{
+			Variant: variant,
+			Version: v.String(),
+			Tag:     byVariant[variant],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Variant < result[j].Variant
+	})
+	return result
+}
+
+// latestVersionItem converts a latest version to its JSON representation
+func latestVersionItem(latest latestVersion) schema.LatestVersion {
+	return schema.LatestVersion{Variant: latest.Variant, Version: latest.Version, Tag: schema.NewTag(latest.Tag)}
+}
+
+func latestVersionItems(latest []latestVersion) []schema.LatestVersion {
+	result := make([]schema.LatestVersion, 0, len(latest))
+	for _, l := range latest {
+		result = append(result, latestVersionItem(l))
+	}
+	return result
+}
+
+func describeConstraint(constraint *semver.Constraint) string {
+	if constraint == nil {
+		return "any semantic version"
+	}
+	return fmt.Sprintf("constraint %q", constraint)
+}
+
+func printLatestVersion(out io.Writer, value interface{}) error {
+	latest := value.(latestVersion)
+	_, err := fmt.Fprintln(out, latest.Tag.Name)
+	return err
+}
+
+func printLatestVersions(out io.Writer, values interface{}) error {
+	versions := values.([]latestVersion)
+	tw := tabwriter.New(out, "    ")
+	for _, header := range []string{"VARIANT", "VERSION", "TAG"} {
+		tw.Column(ansi.Header(header), len(header))
+	}
+	tw.Line()
+	for _, v := range versions {
+		tw.Column(v.Variant, len(v.Variant))
+		tw.Column(v.Version, len(v.Version))
+		tw.Column(v.Tag.Name, len(v.Tag.Name))
+		tw.Line()
+	}
+	return tw.Flush()
+}
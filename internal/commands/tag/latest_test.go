@@ -0,0 +1,57 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/semver"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func TestLatestVersions(t *testing.T) {
+	tags := []hub.Tag{
+		{Name: "repo:latest"},
+		{Name: "repo:1.4"},
+		{Name: "repo:1.4.2"},
+		{Name: "repo:v1.5.0"},
+		{Name: "repo:2.0.0-rc1"},
+		{Name: "repo:1.4.2-alpine"},
+		{Name: "repo:1.4.3-alpine"},
+		{Name: "repo:1.3-slim"},
+	}
+
+	latest := latestVersions(tags, nil, false)
+	assert.DeepEqual(t, latest, []latestVersion{
+		{Variant: "", Version: "1.5.0", Tag: hub.Tag{Name: "repo:v1.5.0"}},
+		{Variant: "alpine", Version: "1.4.3", Tag: hub.Tag{Name: "repo:1.4.3-alpine"}},
+		{Variant: "slim", Version: "1.3.0", Tag: hub.Tag{Name: "repo:1.3-slim"}},
+	})
+
+	constraint, err := semver.ParseConstraint("~1.4")
+	assert.NilError(t, err)
+	latest = latestVersions(tags, constraint, false)
+	assert.DeepEqual(t, latest, []latestVersion{
+		{Variant: "", Version: "1.4.2", Tag: hub.Tag{Name: "repo:1.4.2"}},
+		{Variant: "alpine", Version: "1.4.3", Tag: hub.Tag{Name: "repo:1.4.3-alpine"}},
+	})
+
+	latest = latestVersions(tags, nil, true)
+	assert.Equal(t, latest[0].Tag.Name, "repo:2.0.0-rc1")
+}
@@ -19,6 +19,7 @@ package tag
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,9 +29,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/format/tabwriter"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/internal/semver"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -72,14 +77,7 @@ var (
 			return s, len(s)
 		}},
 		{"SIZE", func(t hub.Tag) (string, int) {
-			size := t.FullSize
-			if len(t.Images) > 0 {
-				size = 0
-				for _, image := range t.Images {
-					size += image.Size
-				}
-			}
-			s := units.HumanSize(float64(size))
+			s := units.HumanSize(float64(tagSize(t)))
 			return s, len(s)
 		}},
 	}
@@ -98,6 +96,11 @@ var (
 			return s, len(s)
 		},
 	}
+	// optionalColumns are only printed when selected with --columns
+	optionalColumns = []column{
+		platformColumn,
+		{"LAST PUSHER", func(t hub.Tag) (string, int) { return t.LastUpdaterUserName, len(t.LastUpdaterUserName) }},
+	}
 )
 
 type column struct {
@@ -105,11 +108,32 @@ type column struct {
 	value  func(t hub.Tag) (string, int)
 }
 
+func columnHeader(c column) string {
+	return c.header
+}
+
+func columnValue(c column, t hub.Tag) string {
+	value, _ := c.value(t)
+	return value
+}
+
+func hasColumn(columns []column, header string) bool {
+	for _, c := range columns {
+		if c.header == header {
+			return true
+		}
+	}
+	return false
+}
+
 type listOptions struct {
 	format.Option
+	tabwriter.Options
 	platforms bool
 	all       bool
 	sort      string
+	filters   []string
+	limit     int
 }
 
 func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
@@ -119,6 +143,7 @@ func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 		Aliases:               []string{"list"},
 		Short:                 "List all the images in a repository",
 		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Repositories(hubClient)),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, lsName)
@@ -129,8 +154,12 @@ func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 	}
 	cmd.Flags().BoolVar(&opts.platforms, "platforms", false, "List all available platforms per tag")
 	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch all available tags")
-	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort tags by (updated|name)[=(asc|desc)] (e.g.: --sort updated or --sort name=desc)")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort tags by (updated|name|semver)[=(asc|desc)] (e.g.: --sort updated or --sort name=desc)")
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", nil,
+		"Filter tags by name, status, pushed, pulled, updated, size or platform (e.g.: --filter name=v1.* --filter pulled<30d --filter size>100MB --filter platform=linux/arm64)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 0, "Maximum number of tags to list")
 	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(columnHeader, defaultColumns, optionalColumns))
 	return cmd
 }
 
@@ -139,40 +168,114 @@ func runList(streams command.Streams, hubClient *hub.Client, opts listOptions, r
 	if err != nil {
 		return err
 	}
-	if opts.all {
+	semverSort, descending, err := semverOrdering(opts.sort)
+	if err != nil {
+		return err
+	}
+	if opts.limit < 0 {
+		return errdef.Validation(fmt.Errorf("invalid limit %d: should be a positive number", opts.limit))
+	}
+	filters, err := parseTagFilters(opts.filters, time.Now())
+	if err != nil {
+		return err
+	}
+	columns, err := tabwriter.SelectColumns(&opts.Options, defaultColumns, optionalColumns, columnHeader)
+	if err != nil {
+		return err
+	}
+	if opts.platforms && !hasColumn(columns, platformColumn.header) {
+		columns = append(columns, platformColumn)
+	}
+	// Filtering or sorting only a page of tags would be misleading
+	fetchAll := opts.all || filters.local() || semverSort
+	if fetchAll || opts.limit > 0 {
 		if err := hubClient.Update(hub.WithAllElements()); err != nil {
 			return err
 		}
 	}
+	if !fetchAll && opts.limit > 0 {
+		// Fetch the pages until there are enough tags
+		fetched := 0
+		if err := hubClient.Update(hub.WithPageHandler(func(page interface{}) error {
+			fetched += len(page.([]hub.Tag))
+			if fetched >= opts.limit {
+				return hub.ErrStopPaging
+			}
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
 
-	var reqOps []hub.RequestOp
+	reqOps := filters.requestOps()
 	if ordering != "" {
 		reqOps = append(reqOps, hub.WithSortingOrder(ordering))
 	}
+	if opts.IsStreaming() && !semverSort {
+		return streamTags(streams.Out(), hubClient, opts, repository, filters, reqOps)
+	}
 	tags, total, err := hubClient.GetTags(repository, reqOps...)
 	if err != nil {
 		return err
 	}
+	hint := "use --all flag to show all"
+	if filters.local() {
+		tags = filters.apply(tags)
+		total = len(tags)
+	}
+	if semverSort {
+		sortTagsBySemver(tags, descending)
+	}
+	if opts.limit > 0 && len(tags) >= opts.limit {
+		tags = tags[:opts.limit]
+		hint = "use --limit flag to show more"
+	}
 
-	if opts.platforms {
-		defaultColumns = append(defaultColumns, platformColumn)
+	list := format.List{
+		Kind:   schema.KindTag,
+		Total:  total,
+		Values: tags,
+		Items:  schema.NewTags(tags),
 	}
+	return opts.PrintList(streams.Out(), list, printTags(total, hint, columns, &opts.Options), tabwriter.Records(columns, columnHeader, columnValue))
+}
 
-	return opts.Print(streams.Out(), tags, printTags(total))
+// streamTags prints the tags page by page, as soon as they are fetched
+func streamTags(out io.Writer, hubClient *hub.Client, opts listOptions, repository string, filters *tagFilter, reqOps []hub.RequestOp) error {
+	printed := 0
+	err := hubClient.Update(hub.WithPageHandler(func(page interface{}) error {
+		tags := filters.apply(page.([]hub.Tag))
+		if opts.limit > 0 && printed+len(tags) > opts.limit {
+			tags = tags[:opts.limit-printed]
+		}
+		printed += len(tags)
+		if err := opts.PrintList(out, format.List{Kind: schema.KindTag, Values: tags, Items: schema.NewTags(tags)}, nil, nil); err != nil {
+			return err
+		}
+		if opts.limit > 0 && printed == opts.limit {
+			return hub.ErrStopPaging
+		}
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+	_, _, err = hubClient.GetTags(repository, reqOps...)
+	return err
 }
 
-func printTags(total int) format.PrettyPrinter {
+func printTags(total int, hint string, columns []column, table *tabwriter.Options) format.PrettyPrinter {
 	return func(out io.Writer, values interface{}) error {
 		tags := values.([]hub.Tag)
-		tw := tabwriter.New(out, "    ")
-		for _, column := range defaultColumns {
+		tw := table.New(out, "    ")
+		for _, column := range columns {
 			tw.Column(ansi.Header(column.header), len(column.header))
 		}
 
 		tw.Line()
 
 		for _, tag := range tags {
-			for _, column := range defaultColumns {
+			for _, column := range columns {
 				value, width := column.value(tag)
 				tw.Column(value, width)
 			}
@@ -183,7 +286,7 @@ func printTags(total int) format.PrettyPrinter {
 		}
 
 		if len(tags) < total {
-			fmt.Fprintln(out, ansi.Info(fmt.Sprintf("%v/%v listed, use --all flag to show all", len(tags), total)))
+			fmt.Fprintln(out, ansi.Info(fmt.Sprintf("%v/%v listed, %s", len(tags), total, hint)))
 		}
 
 		return nil
@@ -191,8 +294,9 @@ func printTags(total int) format.PrettyPrinter {
 }
 
 const (
-	sortAsc  = "asc"
-	sortDesc = "desc"
+	sortAsc    = "asc"
+	sortDesc   = "desc"
+	sortSemver = "semver"
 )
 
 func mapOrdering(order string) (string, error) {
@@ -209,7 +313,7 @@ func mapOrdering(order string) (string, error) {
 			update = "-last_updated"
 		case sortAsc:
 		default:
-			return "", fmt.Errorf(`invalid sorting direction %q: should be either "asc" or "desc"`, fields[1])
+			return "", errdef.Validation(fmt.Errorf(`invalid sorting direction %q: should be either "asc" or "desc"`, fields[1]))
 		}
 	}
 	switch fields[0] {
@@ -217,7 +321,51 @@ func mapOrdering(order string) (string, error) {
 		return update, nil
 	case "name":
 		return name, nil
+	case sortSemver:
+		// Sorted client side, see semverOrdering
+		return "", nil
 	default:
-		return "", fmt.Errorf(`unknown sorting column %q: should be either "name" or "updated"`, fields[0])
+		return "", errdef.Validation(fmt.Errorf(`unknown sorting column %q: should be either "name", "updated" or "semver"`, fields[0]))
 	}
 }
+
+// semverOrdering returns whether the tags are sorted client side by semantic version, and if it is in descending order
+func semverOrdering(order string) (bool, bool, error) {
+	fields := strings.SplitN(order, "=", 2)
+	if fields[0] != sortSemver {
+		return false, false, nil
+	}
+	if len(fields) == 2 {
+		switch fields[1] {
+		case sortDesc:
+			return true, true, nil
+		case sortAsc:
+		default:
+			return false, false, errdef.Validation(fmt.Errorf(`invalid sorting direction %q: should be either "asc" or "desc"`, fields[1]))
+		}
+	}
+	return true, false, nil
+}
+
+// sortTagsBySemver sorts the tags by semantic version, the tags which are not versions being listed last by name
+func sortTagsBySemver(tags []hub.Tag, descending bool) {
+	versions := make(map[string]*semver.Version, len(tags))
+	for _, t := range tags {
+		if v, err := semver.Parse(shortTagName(t)); err == nil {
+			versions[t.Name] = v
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		vi, vj := versions[tags[i].Name], versions[tags[j].Name]
+		switch {
+		case vi == nil && vj == nil:
+			return tags[i].Name < tags[j].Name
+		case vi == nil || vj == nil:
+			return vj == nil
+		}
+		if c := vi.Compare(vj); c != 0 {
+			return (c < 0) != descending
+		}
+		return tags[i].Name < tags[j].Name
+	})
+}
@@ -17,9 +17,21 @@
 package tag
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/docker/cli/cli/streams"
+	"github.com/spf13/pflag"
 	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
 )
 
 func TestMappingSortFieldToOrderingAPI(t *testing.T) {
@@ -38,7 +50,7 @@ func TestMappingSortFieldToOrderingAPI(t *testing.T) {
 			name:          "invalid sort by",
 			sort:          "invalid",
 			ordering:      "",
-			expectedError: `unknown sorting column "invalid": should be either "name" or "updated"`,
+			expectedError: `unknown sorting column "invalid": should be either "name", "updated" or "semver"`,
 		},
 		{
 			name:     "ascending order by default",
@@ -70,6 +82,11 @@ func TestMappingSortFieldToOrderingAPI(t *testing.T) {
 			sort:     "name=desc",
 			ordering: "name",
 		},
+		{
+			name:     "semver is sorted client side",
+			sort:     "semver=desc",
+			ordering: "",
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -82,3 +99,109 @@ func TestMappingSortFieldToOrderingAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestSortTagsBySemver(t *testing.T) {
+	tags := []hub.Tag{
+		{Name: "repo:latest"},
+		{Name: "repo:1.10.0"},
+		{Name: "repo:v1.2"},
+		{Name: "repo:1.2-alpine"},
+		{Name: "repo:1.10.0-rc1"},
+		{Name: "repo:edge"},
+		{Name: "repo:1.9.3"},
+	}
+	sortTagsBySemver(tags, false)
+	assert.DeepEqual(t, tagNames(tags), []string{"repo:1.2-alpine", "repo:v1.2", "repo:1.9.3", "repo:1.10.0-rc1", "repo:1.10.0", "repo:edge", "repo:latest"})
+	sortTagsBySemver(tags, true)
+	assert.DeepEqual(t, tagNames(tags), []string{"repo:1.10.0", "repo:1.10.0-rc1", "repo:1.9.3", "repo:1.2-alpine", "repo:v1.2", "repo:edge", "repo:latest"})
+}
+
+func TestSemverOrdering(t *testing.T) {
+	semverSort, descending, err := semverOrdering("semver")
+	assert.NilError(t, err)
+	assert.Assert(t, semverSort && !descending)
+	semverSort, descending, err = semverOrdering("semver=desc")
+	assert.NilError(t, err)
+	assert.Assert(t, semverSort && descending)
+	semverSort, _, err = semverOrdering("name=desc")
+	assert.NilError(t, err)
+	assert.Assert(t, !semverSort)
+	_, _, err = semverOrdering("semver=up")
+	assert.Error(t, err, `invalid sorting direction "up": should be either "asc" or "desc"`)
+}
+
+type testStreams struct {
+	out *streams.Out
+}
+
+func (s testStreams) In() *streams.In   { return streams.NewIn(io.NopCloser(strings.NewReader(""))) }
+func (s testStreams) Out() *streams.Out { return s.out }
+func (s testStreams) Err() io.Writer    { return io.Discard }
+
+// newTagsServer fakes a repository of 300 tags listed by pages of 100, returning the pages
+// fetched
+func newTagsServer(t *testing.T) (*hub.Client, func() []string) {
+	var (
+		lock  sync.Mutex
+		pages []string
+	)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		assert.NilError(t, err)
+		lock.Lock()
+		pages = append(pages, r.URL.Query().Get("page"))
+		lock.Unlock()
+		var results []string
+		for i := 0; i < 100; i++ {
+			results = append(results, fmt.Sprintf(`{"name": "tag-%d"}`, (page-1)*100+i))
+		}
+		next := ""
+		if page < 3 {
+			next = fmt.Sprintf("%s/v2/repositories/user/repo/tags/?page=%d", server.URL, page+1)
+		}
+		fmt.Fprintf(w, `{"count": 300, "next": %q, "results": [%s]}`, next, strings.Join(results, ","))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("DOCKER_HUB_API_URL", server.URL)
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+	hubClient, err := hub.NewClient()
+	assert.NilError(t, err)
+	return hubClient, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return pages
+	}
+}
+
+func TestListFetchesThePagesUpToTheLimit(t *testing.T) {
+	hubClient, pages := newTagsServer(t)
+	out := bytes.NewBuffer(nil)
+	err := runList(testStreams{out: streams.NewOut(out)}, hubClient, listOptions{limit: 150}, "user/repo")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pages(), []string{"1", "2"})
+	assert.Assert(t, strings.Contains(out.String(), "tag-149"))
+	assert.Assert(t, !strings.Contains(out.String(), "tag-150"))
+	assert.Assert(t, strings.Contains(out.String(), "150/300 listed, use --limit flag to show more"), out.String())
+}
+
+func TestStreamedListStopsAtTheLimit(t *testing.T) {
+	hubClient, pages := newTagsServer(t)
+	opts := listOptions{limit: 150}
+	flags := pflag.NewFlagSet("ls", pflag.ContinueOnError)
+	opts.AddFormatFlag(flags)
+	assert.NilError(t, flags.Parse([]string{"--format", "ndjson"}))
+	out := bytes.NewBuffer(nil)
+	err := runList(testStreams{out: streams.NewOut(out)}, hubClient, opts, "user/repo")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pages(), []string{"1", "2"})
+	assert.Equal(t, strings.Count(out.String(), "\n"), 150)
+}
+
+func tagNames(tags []hub.Tag) []string {
+	var names []string
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
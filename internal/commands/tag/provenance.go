@@ -0,0 +1,357 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	provenanceName = "provenance"
+
+	predicateTypeSLSA = "https://slsa.dev/provenance/"
+	buildArgPrefix    = "build-arg:"
+)
+
+type provenanceOptions struct {
+	format.Option
+	platform string
+}
+
+// Provenance describes how an image was built
+type Provenance struct {
+	Name          string
+	Platform      *ocispec.Platform
+	PredicateType string
+	BuilderID     string
+	BuildType     string
+	Source        Source
+	BuildArgs     map[string]string
+	Materials     []Material
+	StartedOn     *time.Time
+	FinishedOn    *time.Time
+}
+
+// Source is the repository and commit an image was built from
+type Source struct {
+	Repository string
+	Commit     string
+	EntryPoint string
+}
+
+// Material is an artifact used by the build, like a base image
+type Material struct {
+	URI    string
+	Digest map[string]string
+}
+
+func newProvenanceCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts provenanceOptions
+	cmd := &cobra.Command{
+		Use:                   provenanceName + " [OPTIONS] REPOSITORY:TAG",
+		Short:                 "Show how an image was built from its SLSA provenance attestation",
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Tags(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, provenanceName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runProvenance(streams, hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&opts.platform, "platform", "", `Select a platform if the tag is a multi-architecture image`)
+	opts.AddFormatFlag(cmd.Flags())
+	return cmd
+}
+
+func runProvenance(streams command.Streams, hubClient *hub.Client, opts provenanceOptions, imageRef string) error {
+	var platform *ocispec.Platform
+	if opts.platform != "" {
+		p, err := platforms.Parse(opts.platform)
+		if err != nil {
+			return errdef.Validation(fmt.Errorf("invalid platform %q: %s", opts.platform, err))
+		}
+		platform = &p
+	}
+	attestations, err := readImageStatements(hubClient.Ctx, newResolver(hubClient), imageRef, platform, isProvenancePredicate)
+	if err != nil {
+		return err
+	}
+	if len(attestations.Statements) == 0 {
+		return fmt.Errorf("no provenance attached to %q (%s)", imageRef, formatPlatform(attestations.Platform))
+	}
+	provenance, err := readProvenance(attestations.Statements[0])
+	if err != nil {
+		return err
+	}
+	provenance.Name = imageRef
+	provenance.Platform = attestations.Platform
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindProvenance, Value: provenance, Item: provenanceItem(provenance)}, printProvenance)
+}
+
+// provenanceItem converts a provenance to its JSON representation
+func provenanceItem(provenance *Provenance) schema.Provenance {
+	platform := ""
+	if provenance.Platform != nil {
+		platform = platforms.Format(*provenance.Platform)
+	}
+	buildArgs := map[string]string{}
+	for k, v := range provenance.BuildArgs {
+		buildArgs[k] = v
+	}
+	materials := make([]schema.Material, 0, len(provenance.Materials))
+	for _, m := range provenance.Materials {
+		digests := map[string]string{}
+		for k, v := range m.Digest {
+			digests[k] = v
+		}
+		materials = append(materials, schema.Material{URI: m.URI, Digest: digests})
+	}
+	return schema.Provenance{
+		Name:          provenance.Name,
+		Platform:      platform,
+		PredicateType: provenance.PredicateType,
+		BuilderID:     provenance.BuilderID,
+		BuildType:     provenance.BuildType,
+		Source: schema.ProvenanceSource{
+			Repository: provenance.Source.Repository,
+			Commit:     provenance.Source.Commit,
+			EntryPoint: provenance.Source.EntryPoint,
+		},
+		BuildArgs:  buildArgs,
+		Materials:  materials,
+		StartedOn:  provenance.StartedOn,
+		FinishedOn: provenance.FinishedOn,
+	}
+}
+
+func isProvenancePredicate(predicateType string) bool {
+	return strings.HasPrefix(predicateType, predicateTypeSLSA)
+}
+
+type slsaDigestSet map[string]string
+
+type slsaMaterial struct {
+	URI    string        `json:"uri"`
+	Digest slsaDigestSet `json:"digest"`
+}
+
+type slsaConfigSource struct {
+	URI        string        `json:"uri"`
+	Digest     slsaDigestSet `json:"digest"`
+	EntryPoint string        `json:"entryPoint"`
+	Path       string        `json:"path"`
+}
+
+type slsaParameters struct {
+	Args map[string]string `json:"args"`
+}
+
+// buildKitVCS is the git repository BuildKit records in its provenance metadata
+type buildKitVCS struct {
+	VCS struct {
+		Source   string `json:"source"`
+		Revision string `json:"revision"`
+	} `json:"vcs"`
+}
+
+// slsaProvenanceV02 is the SLSA provenance v0.2 predicate
+type slsaProvenanceV02 struct {
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	BuildType  string `json:"buildType"`
+	Invocation struct {
+		ConfigSource slsaConfigSource `json:"configSource"`
+		Parameters   slsaParameters   `json:"parameters"`
+	} `json:"invocation"`
+	Materials []slsaMaterial `json:"materials"`
+	Metadata  struct {
+		BuildStartedOn  *time.Time  `json:"buildStartedOn"`
+		BuildFinishedOn *time.Time  `json:"buildFinishedOn"`
+		BuildKit        buildKitVCS `json:"https://mobyproject.org/buildkit@v1#metadata"`
+	} `json:"metadata"`
+}
+
+// slsaProvenanceV1 is the SLSA provenance v1 predicate
+type slsaProvenanceV1 struct {
+	BuildDefinition struct {
+		BuildType          string `json:"buildType"`
+		ExternalParameters struct {
+			ConfigSource slsaConfigSource `json:"configSource"`
+			Request      slsaParameters   `json:"request"`
+		} `json:"externalParameters"`
+		ResolvedDependencies []slsaMaterial `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Metadata struct {
+			StartedOn  *time.Time  `json:"startedOn"`
+			FinishedOn *time.Time  `json:"finishedOn"`
+			BuildKit   buildKitVCS `json:"https://mobyproject.org/buildkit@v1#metadata"`
+		} `json:"metadata"`
+	} `json:"runDetails"`
+}
+
+// readProvenance decodes SLSA provenance v0.2 and v1 statements
+func readProvenance(statement Statement) (*Provenance, error) {
+	provenance := &Provenance{PredicateType: statement.PredicateType}
+	var (
+		configSource slsaConfigSource
+		args         map[string]string
+		materials    []slsaMaterial
+		vcs          buildKitVCS
+	)
+	if strings.HasPrefix(statement.PredicateType, predicateTypeSLSA+"v0.") {
+		var predicate slsaProvenanceV02
+		if err := json.Unmarshal(statement.Predicate, &predicate); err != nil {
+			return nil, fmt.Errorf("failed to decode provenance: %w", err)
+		}
+		provenance.BuilderID = predicate.Builder.ID
+		provenance.BuildType = predicate.BuildType
+		provenance.StartedOn = predicate.Metadata.BuildStartedOn
+		provenance.FinishedOn = predicate.Metadata.BuildFinishedOn
+		configSource = predicate.Invocation.ConfigSource
+		args = predicate.Invocation.Parameters.Args
+		materials = predicate.Materials
+		vcs = predicate.Metadata.BuildKit
+	} else {
+		var predicate slsaProvenanceV1
+		if err := json.Unmarshal(statement.Predicate, &predicate); err != nil {
+			return nil, fmt.Errorf("failed to decode provenance: %w", err)
+		}
+		provenance.BuilderID = predicate.RunDetails.Builder.ID
+		provenance.BuildType = predicate.BuildDefinition.BuildType
+		provenance.StartedOn = predicate.RunDetails.Metadata.StartedOn
+		provenance.FinishedOn = predicate.RunDetails.Metadata.FinishedOn
+		configSource = predicate.BuildDefinition.ExternalParameters.ConfigSource
+		args = predicate.BuildDefinition.ExternalParameters.Request.Args
+		materials = predicate.BuildDefinition.ResolvedDependencies
+		vcs = predicate.RunDetails.Metadata.BuildKit
+	}
+
+	// BuildKit records the git repository in its metadata, the config source
+	// being the build context
+	provenance.Source = Source{
+		Repository: vcs.VCS.Source,
+		Commit:     vcs.VCS.Revision,
+		EntryPoint: configSource.EntryPoint,
+	}
+	if provenance.Source.EntryPoint == "" {
+		provenance.Source.EntryPoint = configSource.Path
+	}
+	if provenance.Source.Repository == "" {
+		provenance.Source.Repository = configSource.URI
+	}
+	if provenance.Source.Commit == "" {
+		provenance.Source.Commit = configSource.Digest["sha1"]
+	}
+
+	for k, v := range args {
+		if strings.HasPrefix(k, buildArgPrefix) {
+			if provenance.BuildArgs == nil {
+				provenance.BuildArgs = map[string]string{}
+			}
+			provenance.BuildArgs[strings.TrimPrefix(k, buildArgPrefix)] = v
+		}
+	}
+	for _, m := range materials {
+		provenance.Materials = append(provenance.Materials, Material{URI: m.URI, Digest: m.Digest})
+	}
+	return provenance, nil
+}
+
+func printProvenance(out io.Writer, value interface{}) error {
+	provenance := value.(*Provenance)
+	fmt.Fprintf(out, ansi.Title("Provenance:")+"\n")
+	fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s\n", provenance.Name)
+	fmt.Fprintf(out, ansi.Key("Platform:")+"\t%s\n", formatPlatform(provenance.Platform))
+	fmt.Fprintf(out, ansi.Key("PredicateType:")+"\t%s\n", provenance.PredicateType)
+	if provenance.BuilderID != "" {
+		fmt.Fprintf(out, ansi.Key("Builder:")+"\t%s\n", provenance.BuilderID)
+	}
+	if provenance.BuildType != "" {
+		fmt.Fprintf(out, ansi.Key("BuildType:")+"\t%s\n", provenance.BuildType)
+	}
+	if provenance.StartedOn != nil {
+		fmt.Fprintf(out, ansi.Key("Started:")+"\t%s (%s ago)\n", provenance.StartedOn.Format(time.RFC3339), units.HumanDuration(time.Since(*provenance.StartedOn)))
+	}
+	if provenance.StartedOn != nil && provenance.FinishedOn != nil {
+		fmt.Fprintf(out, ansi.Key("Duration:")+"\t%s\n", provenance.FinishedOn.Sub(*provenance.StartedOn))
+	}
+
+	fmt.Fprintf(out, "\n")
+
+	fmt.Fprintf(out, ansi.Title("Source:")+"\n")
+	if provenance.Source.Repository != "" {
+		fmt.Fprintf(out, ansi.Key("Repository:")+"\t%s\n", provenance.Source.Repository)
+	}
+	if provenance.Source.Commit != "" {
+		fmt.Fprintf(out, ansi.Key("Commit:")+"\t\t%s\n", provenance.Source.Commit)
+	}
+	if provenance.Source.EntryPoint != "" {
+		fmt.Fprintf(out, ansi.Key("EntryPoint:")+"\t%s\n", provenance.Source.EntryPoint)
+	}
+	if len(provenance.BuildArgs) > 0 {
+		fmt.Fprintf(out, ansi.Key("Build args:")+"\n")
+		for _, k := range sortMapKeys(provenance.BuildArgs) {
+			fmt.Fprintf(out, "    %s=%s\n", k, provenance.BuildArgs[k])
+		}
+	}
+
+	fmt.Fprintf(out, "\n")
+
+	fmt.Fprintln(out, ansi.Title("Materials:"))
+	for i, m := range provenance.Materials {
+		if i != 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, ansi.Key("URI:")+"\t\t%s\n", m.URI)
+		algorithms := make([]string, 0, len(m.Digest))
+		for algorithm := range m.Digest {
+			algorithms = append(algorithms, algorithm)
+		}
+		sort.Strings(algorithms)
+		for _, algorithm := range algorithms {
+			fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s:%s\n", algorithm, m.Digest[algorithm])
+		}
+	}
+	return nil
+}
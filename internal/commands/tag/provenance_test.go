@@ -0,0 +1,95 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestReadProvenanceV02(t *testing.T) {
+	statement, err := readStatement([]byte(`{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "subject": [{"name": "pkg:docker/user/repo@latest?platform=linux%2Famd64", "digest": {"sha256": "amd64"}}],
+  "predicate": {
+    "builder": {"id": "https://github.com/user/repo/actions/runs/42"},
+    "buildType": "https://mobyproject.org/buildkit@v1",
+    "materials": [
+      {"uri": "pkg:docker/alpine@3.18?platform=linux%2Famd64", "digest": {"sha256": "c0ffee"}}
+    ],
+    "invocation": {
+      "configSource": {"entryPoint": "Dockerfile"},
+      "parameters": {"frontend": "dockerfile.v0", "args": {"build-arg:VERSION": "1.2.3", "build-arg:COMMIT": "abc123", "label:foo": "bar"}}
+    },
+    "metadata": {
+      "buildStartedOn": "2023-05-04T10:00:00Z",
+      "buildFinishedOn": "2023-05-04T10:02:30Z",
+      "https://mobyproject.org/buildkit@v1#metadata": {"vcs": {"source": "https://github.com/user/repo", "revision": "abc123"}}
+    }
+  }
+}`))
+	assert.NilError(t, err)
+	provenance, err := readProvenance(statement)
+	assert.NilError(t, err)
+	assert.Equal(t, provenance.BuilderID, "https://github.com/user/repo/actions/runs/42")
+	assert.DeepEqual(t, provenance.Source, Source{Repository: "https://github.com/user/repo", Commit: "abc123", EntryPoint: "Dockerfile"})
+	assert.DeepEqual(t, provenance.BuildArgs, map[string]string{"VERSION": "1.2.3", "COMMIT": "abc123"})
+	assert.DeepEqual(t, provenance.Materials, []Material{{URI: "pkg:docker/alpine@3.18?platform=linux%2Famd64", Digest: map[string]string{"sha256": "c0ffee"}}})
+
+	// Avoid relative dates in the golden file
+	provenance.StartedOn = nil
+	provenance.Name = "user/repo:latest"
+	provenance.Platform = &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	out := bytes.NewBuffer(nil)
+	assert.NilError(t, printProvenance(out, provenance))
+	golden.Assert(t, out.String(), "provenance.golden")
+}
+
+func TestReadProvenanceV1(t *testing.T) {
+	statement, err := readStatement([]byte(`{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {
+    "buildDefinition": {
+      "buildType": "https://mobyproject.org/buildkit@v1",
+      "externalParameters": {
+        "configSource": {"uri": "https://github.com/user/repo.git#main", "digest": {"sha1": "def456"}, "path": "build/Dockerfile"},
+        "request": {"args": {"build-arg:GO_VERSION": "1.21"}}
+      },
+      "resolvedDependencies": [{"uri": "pkg:docker/golang@1.21", "digest": {"sha256": "beef"}}]
+    },
+    "runDetails": {
+      "builder": {"id": "builder-v1"},
+      "metadata": {"startedOn": "2023-05-04T10:00:00Z", "finishedOn": "2023-05-04T10:01:00Z"}
+    }
+  }
+}`))
+	assert.NilError(t, err)
+	provenance, err := readProvenance(statement)
+	assert.NilError(t, err)
+	assert.Equal(t, provenance.BuilderID, "builder-v1")
+	assert.DeepEqual(t, provenance.Source, Source{Repository: "https://github.com/user/repo.git#main", Commit: "def456", EntryPoint: "build/Dockerfile"})
+	assert.DeepEqual(t, provenance.BuildArgs, map[string]string{"GO_VERSION": "1.21"})
+	assert.Equal(t, provenance.FinishedOn.Sub(*provenance.StartedOn), time.Minute)
+	assert.Equal(t, len(provenance.Materials), 1)
+}
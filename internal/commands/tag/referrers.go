@@ -0,0 +1,193 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	containerdreference "github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	log "github.com/sirupsen/logrus"
+)
+
+// getReferrers lists the manifests whose subject is the given digest, like
+// signatures or SBOMs. It uses the OCI 1.1 referrers API, falling back on
+// the "sha256-<hex>" tag schema for registries not supporting it.
+func getReferrers(ctx context.Context, hosts docker.RegistryHosts, resolver remotes.Resolver, name reference.Named, dgst digest.Digest) ([]ocispec.Descriptor, error) {
+	index, err := fetchReferrersIndex(ctx, hosts, name, dgst)
+	if err == nil {
+		return index.Manifests, nil
+	}
+	if !errdefs.IsNotImplemented(err) {
+		return nil, err
+	}
+	log.Debugf("Referrers API not available, falling back on the tag schema: %s", err)
+
+	tag := strings.Replace(dgst.String(), ":", "-", 1)
+	fullName, descriptor, err := resolver.Resolve(ctx, fmt.Sprintf("%s:%s", name.Name(), tag))
+	if errdefs.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, err := getBlob(ctx, resolver, fullName, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	var fallback ocispec.Index
+	if err := json.Unmarshal(raw, &fallback); err != nil {
+		return nil, err
+	}
+	return fallback.Manifests, nil
+}
+
+// fetchReferrersIndex calls the referrers API, following its pagination, and
+// returns a not implemented error if the registry does not support it
+func fetchReferrersIndex(ctx context.Context, hosts docker.RegistryHosts, name reference.Named, dgst digest.Digest) (*ocispec.Index, error) {
+	registryHosts, err := hosts(reference.Domain(name))
+	if err != nil {
+		return nil, err
+	}
+	if len(registryHosts) == 0 {
+		return nil, fmt.Errorf("no registry host for %q", reference.Domain(name))
+	}
+	host := registryHosts[0]
+	refspec, err := containerdreference.Parse(name.Name())
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = docker.ContextWithRepositoryScope(ctx, refspec, false)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s://%s%s/%s/referrers/%s", host.Scheme, host.Host, host.Path, reference.Path(name), dgst))
+	if err != nil {
+		return nil, err
+	}
+	var index *ocispec.Index
+	for u != nil {
+		page, next, err := fetchReferrersPage(ctx, host, u, dgst)
+		if err != nil {
+			// Only the first page tells whether the registry supports the API
+			if index != nil && errdefs.IsNotImplemented(err) {
+				return nil, fmt.Errorf("failed to list referrers of %s: %s", dgst, err)
+			}
+			return nil, err
+		}
+		if index == nil {
+			index = page
+		} else {
+			index.Manifests = append(index.Manifests, page.Manifests...)
+		}
+		u = next
+	}
+	return index, nil
+}
+
+// fetchReferrersPage fetches a page of the referrers API, returning the URL of
+// the next page given by the Link header, if any
+func fetchReferrersPage(ctx context.Context, host docker.RegistryHost, u *url.URL, dgst digest.Digest) (*ocispec.Index, *url.URL, error) {
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Accept", ocispec.MediaTypeImageIndex)
+		if host.Authorizer != nil {
+			if err := host.Authorizer.Authorize(ctx, req); err != nil {
+				return nil, nil, err
+			}
+		}
+		log.Debugf("HTTP %s on: %s", req.Method, req.URL)
+		resp, err = host.Client.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Get a token for the repository scope, then try again
+		if resp.StatusCode == http.StatusUnauthorized && host.Authorizer != nil && attempt == 0 {
+			err := host.Authorizer.AddResponses(ctx, []*http.Response{resp})
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		break
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch {
+	case resp.StatusCode == http.StatusOK && strings.HasPrefix(resp.Header.Get("Content-Type"), ocispec.MediaTypeImageIndex):
+	case resp.StatusCode == http.StatusOK, resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusBadRequest, resp.StatusCode == http.StatusMethodNotAllowed:
+		return nil, nil, fmt.Errorf("referrers API returned %q: %w", resp.Status, errdefs.ErrNotImplemented)
+	default:
+		return nil, nil, fmt.Errorf("failed to list referrers of %s: bad status code %q", dgst, resp.Status)
+	}
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(buf, &index); err != nil {
+		return nil, nil, err
+	}
+	next, err := nextLink(u, resp.Header.Values("Link"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &index, next, nil
+}
+
+// nextLink returns the URL of the Link header with the "next" relation, like
+// `</v2/user/repo/referrers/sha256:...?n=10&last=sha256:...>; rel="next"`,
+// resolved against the URL of the current page
+func nextLink(u *url.URL, links []string) (*url.URL, error) {
+	for _, header := range links {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if key != "rel" || strings.Trim(value, `"`) != "next" {
+					continue
+				}
+				next, err := u.Parse(strings.Trim(target, "<>"))
+				if err != nil {
+					return nil, fmt.Errorf("invalid Link header %q: %w", header, err)
+				}
+				return next, nil
+			}
+		}
+	}
+	return nil, nil
+}
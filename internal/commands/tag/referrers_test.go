@@ -0,0 +1,85 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestFetchReferrersIndexFollowsThePagination(t *testing.T) {
+	subject := digest.FromString("subject")
+	pages := [][]ocispec.Descriptor{
+		{{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("signature")}},
+		{{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("sbom")}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v2/user/repo/referrers/"+subject.String())
+		page := 0
+		if r.URL.Query().Get("last") != "" {
+			page = 1
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?n=1&last=%s>; rel="next"`, r.URL.Path, pages[0][0].Digest))
+		}
+		w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+		_ = json.NewEncoder(w).Encode(ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: pages[page]})
+	}))
+	defer server.Close()
+
+	name, err := reference.ParseNormalizedNamed(strings.TrimPrefix(server.URL, "http://") + "/user/repo")
+	assert.NilError(t, err)
+	hosts := docker.ConfigureDefaultRegistries(docker.WithPlainHTTP(docker.MatchAllHosts))
+	index, err := fetchReferrersIndex(context.Background(), hosts, name, subject)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, index.Manifests, append(pages[0], pages[1]...))
+}
+
+func TestNextLink(t *testing.T) {
+	current, err := url.Parse("https://registry.example.com/v2/user/repo/referrers/sha256:abc")
+	assert.NilError(t, err)
+	testCases := []struct {
+		links    []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{`</v2/user/repo/referrers/sha256:abc?last=x>; rel="next"`}, "https://registry.example.com/v2/user/repo/referrers/sha256:abc?last=x"},
+		{[]string{`<https://other.example.com/page>;rel=next`}, "https://other.example.com/page"},
+		{[]string{`</first>; rel="first", </next>; rel="next"`}, "https://registry.example.com/next"},
+		{[]string{`</prev>; rel="prev"`}, ""},
+	}
+	for _, tc := range testCases {
+		next, err := nextLink(current, tc.links)
+		assert.NilError(t, err)
+		if tc.expected == "" {
+			assert.Assert(t, next == nil, tc.links)
+			continue
+		}
+		assert.Equal(t, next.String(), tc.expected)
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/distribution/reference"
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/errdef"
 	"github.com/docker/hub-tool/internal/metrics"
 	"github.com/docker/hub-tool/pkg/hub"
@@ -38,21 +40,25 @@ const (
 )
 
 type rmOptions struct {
-	force bool
+	force  bool
+	filter string
+	digest string
 }
 
 func newRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
 	var opts rmOptions
 	cmd := &cobra.Command{
-		Use:                   rmName + " [OPTIONS] REPOSITORY:TAG",
-		Short:                 "Delete a tag in a repository",
-		Args:                  cli.ExactArgs(1),
+		Use:                   rmName + " [OPTIONS] REPOSITORY:TAG [REPOSITORY:TAG...]",
+		Short:                 "Delete one or more tags in a repository",
+		Long:                  "Delete one or more tags in a repository. With --filter or --digest, a single REPOSITORY is expected and every matching tag is deleted.",
+		Args:                  cli.RequiresMinArgs(1),
+		ValidArgsFunction:     completion.Tags(hubClient),
 		DisableFlagsInUseLine: true,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, rmName)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := runRm(cmd.Context(), streams, hubClient, opts, args[0])
+			err := runRm(cmd.Context(), streams, hubClient, opts, args)
 			if err == nil || errors.Is(err, errdef.ErrCanceled) {
 				return nil
 			}
@@ -60,44 +66,148 @@ func newRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *co
 		},
 	}
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Force deletion of the tag")
+	cmd.Flags().StringVar(&opts.filter, "filter", "", "Delete all the tags of the repository matching a regular expression")
+	cmd.Flags().StringVar(&opts.digest, "digest", "", "Delete all the tags of the repository pointing to a manifest digest")
 	return cmd
 }
 
-func runRm(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts rmOptions, image string) error {
-	normRef, err := reference.ParseNormalizedNamed(image)
+func runRm(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts rmOptions, args []string) error {
+	refs, err := resolveRmTags(hubClient, opts, args)
 	if err != nil {
 		return err
 	}
-	normRef = reference.TagNameOnly(normRef)
-	ref, ok := normRef.(reference.NamedTagged)
-	if !ok {
-		return fmt.Errorf("invalid reference: tag must be specified")
+	if len(refs) == 0 {
+		fmt.Fprintln(streams.Out(), ansi.Info("No tag to delete"))
+		return nil
 	}
 
 	if !opts.force {
+		if err := confirmRm(ctx, streams, refs); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, ref := range refs {
+		name := fmt.Sprintf("%s:%s", reference.FamiliarName(ref), ref.Tag())
+		if err := hubClient.RemoveTag(reference.FamiliarName(ref), ref.Tag()); err != nil {
+			fmt.Fprintln(streams.Err(), ansi.Error(fmt.Sprintf("Failed to delete %s: %s", name, err)))
+			failed++
+			continue
+		}
+		fmt.Fprintln(streams.Out(), "Deleted", name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d/%d tag(s)", failed, len(refs))
+	}
+	return nil
+}
+
+func resolveRmTags(hubClient *hub.Client, opts rmOptions, args []string) ([]reference.NamedTagged, error) {
+	if opts.filter == "" && opts.digest == "" {
+		var refs []reference.NamedTagged
+		for _, arg := range args {
+			normRef, err := reference.ParseNormalizedNamed(arg)
+			if err != nil {
+				return nil, err
+			}
+			ref, ok := reference.TagNameOnly(normRef).(reference.NamedTagged)
+			if !ok {
+				return nil, fmt.Errorf("invalid reference %q: tag must be specified", arg)
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
+	}
+
+	if len(args) != 1 {
+		return nil, errdef.Validation(errors.New("a single repository must be given when using --filter or --digest"))
+	}
+	repository, err := reference.ParseNormalizedNamed(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := repository.(reference.Tagged); ok {
+		return nil, errdef.Validation(fmt.Errorf("invalid repository %q: tag must not be specified when using --filter or --digest", args[0]))
+	}
+	var filter *regexp.Regexp
+	if opts.filter != "" {
+		if filter, err = regexp.Compile(opts.filter); err != nil {
+			return nil, errdef.Validation(fmt.Errorf("invalid filter %q: %s", opts.filter, err))
+		}
+	}
+
+	if err := hubClient.Update(hub.WithAllElements()); err != nil {
+		return nil, err
+	}
+	tags, _, err := hubClient.GetTags(reference.FamiliarName(repository))
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []reference.NamedTagged
+	for _, name := range matchTags(tags, reference.FamiliarName(repository), filter, opts.digest) {
+		ref, err := reference.WithTag(repository, name)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// matchTags returns the names of the tags matching both the filter and the digest, when set
+func matchTags(tags []hub.Tag, repository string, filter *regexp.Regexp, digest string) []string {
+	var names []string
+	for _, t := range tags {
+		name := strings.TrimPrefix(t.Name, repository+":")
+		if filter != nil && !filter.MatchString(name) {
+			continue
+		}
+		if digest != "" && !tagPointsTo(t, digest) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func tagPointsTo(t hub.Tag, digest string) bool {
+	if t.Digest != "" {
+		return t.Digest == digest
+	}
+	// Older Hub responses only carry the digest of each image
+	return len(t.Images) == 1 && t.Images[0].Digest == digest
+}
+
+func confirmRm(ctx context.Context, streams command.Streams, refs []reference.NamedTagged) error {
+	if len(refs) == 1 {
+		ref := refs[0]
 		fmt.Fprintln(streams.Out(), ansi.Warn(fmt.Sprintf(`WARNING: You are about to permanently delete image "%s:%s"`, reference.FamiliarName(ref), ref.Tag())))
 		fmt.Fprintln(streams.Out(), ansi.Warn("         This action is irreversible"))
 		fmt.Fprintf(streams.Out(), ansi.Info("Are you sure you want to delete the image tagged %q from repository %q? [y/N] "), ref.Tag(), reference.FamiliarName(ref))
-		userIn := make(chan string, 1)
-		go func() {
-			reader := bufio.NewReader(streams.In())
-			input, _ := reader.ReadString('\n')
-			userIn <- strings.ToLower(strings.TrimSpace(input))
-		}()
-		input := ""
-		select {
-		case <-ctx.Done():
-			return errdef.ErrCanceled
-		case input = <-userIn:
-		}
-		if strings.ToLower(input) != "y" {
-			return errors.New("deletion aborted")
+	} else {
+		fmt.Fprintln(streams.Out(), ansi.Warn(fmt.Sprintf("WARNING: You are about to permanently delete %d images:", len(refs))))
+		for _, ref := range refs {
+			fmt.Fprintf(streams.Out(), "    %s:%s\n", reference.FamiliarName(ref), ref.Tag())
 		}
+		fmt.Fprintln(streams.Out(), ansi.Warn("         This action is irreversible"))
+		fmt.Fprintf(streams.Out(), ansi.Info("Are you sure you want to delete these %d images? [y/N] "), len(refs))
 	}
-
-	if err := hubClient.RemoveTag(reference.FamiliarName(ref), ref.Tag()); err != nil {
-		return err
+	userIn := make(chan string, 1)
+	go func() {
+		reader := bufio.NewReader(streams.In())
+		input, _ := reader.ReadString('\n')
+		userIn <- strings.ToLower(strings.TrimSpace(input))
+	}()
+	input := ""
+	select {
+	case <-ctx.Done():
+		return errdef.ErrCanceled
+	case input = <-userIn:
+	}
+	if input != "y" {
+		return errors.New("deletion aborted")
 	}
-	fmt.Fprintln(streams.Out(), "Deleted", image)
 	return nil
 }
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"regexp"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func TestMatchTags(t *testing.T) {
+	tags := []hub.Tag{
+		{Name: "user/repo:1.0", Digest: "sha256:aaa"},
+		{Name: "user/repo:1.0-rc1", Digest: "sha256:bbb"},
+		{Name: "user/repo:latest", Digest: "sha256:aaa"},
+		{Name: "user/repo:legacy", Images: []hub.Image{{Digest: "sha256:aaa"}}},
+		{Name: "user/repo:multi", Images: []hub.Image{{Digest: "sha256:aaa"}, {Digest: "sha256:ccc"}}},
+	}
+	testCases := []struct {
+		name     string
+		filter   string
+		digest   string
+		expected []string
+	}{
+		{
+			name:     "filter",
+			filter:   `-rc\d+$`,
+			expected: []string{"1.0-rc1"},
+		},
+		{
+			name:     "digest",
+			digest:   "sha256:aaa",
+			expected: []string{"1.0", "latest", "legacy"},
+		},
+		{
+			name:     "filter and digest",
+			filter:   `^\d`,
+			digest:   "sha256:aaa",
+			expected: []string{"1.0"},
+		},
+		{
+			name:   "no match",
+			filter: "^nope$",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var filter *regexp.Regexp
+			if testCase.filter != "" {
+				filter = regexp.MustCompile(testCase.filter)
+			}
+			assert.DeepEqual(t, matchTags(tags, "user/repo", filter, testCase.digest), testCase.expected)
+		})
+	}
+}
@@ -0,0 +1,358 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	sbomName = "sbom"
+
+	predicateTypeSPDX      = "https://spdx.dev/Document"
+	predicateTypeCycloneDX = "https://cyclonedx.org/bom"
+
+	noAssertion = "NOASSERTION"
+)
+
+type sbomOptions struct {
+	format.Option
+	platform string
+	output   string
+	licenses bool
+}
+
+// Package is a software package listed in an SBOM
+type Package struct {
+	Name    string
+	Version string
+	Type    string
+	License string
+}
+
+// License is the number of packages released under a license
+type License struct {
+	License  string
+	Packages int
+}
+
+func newSbomCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts sbomOptions
+	cmd := &cobra.Command{
+		Use:                   sbomName + " [OPTIONS] REPOSITORY:TAG",
+		Short:                 "List the packages of the SBOM attached to an image",
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Tags(hubClient)),
+		DisableFlagsInUseLine: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, sbomName)
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runSbom(streams, hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&opts.platform, "platform", "", `Select a platform if the tag is a multi-architecture image`)
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", `Export the raw SBOM to a file ("-" for the standard output)`)
+	cmd.Flags().BoolVar(&opts.licenses, "licenses", false, "Summarize the licenses of the packages")
+	opts.AddFormatFlag(cmd.Flags())
+	return cmd
+}
+
+func runSbom(streams command.Streams, hubClient *hub.Client, opts sbomOptions, imageRef string) error {
+	var platform *ocispec.Platform
+	if opts.platform != "" {
+		p, err := platforms.Parse(opts.platform)
+		if err != nil {
+			return errdef.Validation(fmt.Errorf("invalid platform %q: %s", opts.platform, err))
+		}
+		platform = &p
+	}
+	attestations, err := readImageStatements(hubClient.Ctx, newResolver(hubClient), imageRef, platform, isSbomPredicate)
+	if err != nil {
+		return err
+	}
+	if len(attestations.Statements) == 0 {
+		return fmt.Errorf("no SBOM attached to %q (%s)", imageRef, formatPlatform(attestations.Platform))
+	}
+
+	if opts.output != "" {
+		return exportSbom(streams.Out(), opts.output, attestations.Statements)
+	}
+
+	packages, err := sbomPackages(attestations.Statements)
+	if err != nil {
+		return err
+	}
+	if opts.licenses {
+		licenses := summarizeLicenses(packages)
+		return opts.PrintList(streams.Out(), format.List{Kind: schema.KindLicense, Total: len(licenses), Values: licenses, Items: licenseItems(licenses)}, printLicenses, nil)
+	}
+	return opts.PrintList(streams.Out(), format.List{Kind: schema.KindPackage, Total: len(packages), Values: packages, Items: packageItems(packages)}, printPackages, nil)
+}
+
+// packageItems converts packages to their JSON representation
+func packageItems(packages []Package) []schema.Package {
+	result := make([]schema.Package, 0, len(packages))
+	for _, p := range packages {
+		result = append(result, schema.Package{Name: p.Name, Version: p.Version, Type: p.Type, License: p.License})
+	}
+	return result
+}
+
+// licenseItems converts licenses to their JSON representation
+func licenseItems(licenses []License) []schema.License {
+	result := make([]schema.License, 0, len(licenses))
+	for _, l := range licenses {
+		result = append(result, schema.License{License: l.License, Packages: l.Packages})
+	}
+	return result
+}
+
+func isSbomPredicate(predicateType string) bool {
+	return strings.HasPrefix(predicateType, predicateTypeSPDX) || strings.HasPrefix(predicateType, predicateTypeCycloneDX)
+}
+
+// exportSbom writes the SBOM documents, wrapped in a JSON array if the image has several
+func exportSbom(out io.Writer, output string, statements []Statement) error {
+	raw := []byte(statements[0].Predicate)
+	if len(statements) > 1 {
+		documents := make([]json.RawMessage, 0, len(statements))
+		for _, s := range statements {
+			documents = append(documents, s.Predicate)
+		}
+		var err error
+		if raw, err = json.Marshal(documents); err != nil {
+			return err
+		}
+	}
+	if output == "-" {
+		_, err := out.Write(raw)
+		return err
+	}
+	return os.WriteFile(output, raw, 0644)
+}
+
+// sbomPackages returns the packages of the SPDX and CycloneDX documents, sorted by name and version
+func sbomPackages(statements []Statement) ([]Package, error) {
+	seen := map[Package]bool{}
+	var packages []Package
+	for _, s := range statements {
+		var (
+			pkgs []Package
+			err  error
+		)
+		if strings.HasPrefix(s.PredicateType, predicateTypeSPDX) {
+			pkgs, err = spdxPackages(s.Predicate)
+		} else {
+			pkgs, err = cycloneDXPackages(s.Predicate)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s SBOM: %w", s.PredicateType, err)
+		}
+		for _, p := range pkgs {
+			if !seen[p] {
+				seen[p] = true
+				packages = append(packages, p)
+			}
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].Version < packages[j].Version
+	})
+	return packages, nil
+}
+
+type spdxDocument struct {
+	Packages []struct {
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func spdxPackages(raw []byte) ([]Package, error) {
+	var document spdxDocument
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, p := range document.Packages {
+		pkg := Package{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			License: p.LicenseConcluded,
+		}
+		if pkg.License == "" || pkg.License == noAssertion {
+			pkg.License = p.LicenseDeclared
+		}
+		if pkg.License == noAssertion {
+			pkg.License = ""
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				pkg.Type = purlType(ref.ReferenceLocator)
+				break
+			}
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+type cycloneDXDocument struct {
+	Components []struct {
+		Name     string `json:"name"`
+		Version  string `json:"version"`
+		Type     string `json:"type"`
+		PURL     string `json:"purl"`
+		Licenses []struct {
+			License struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"license"`
+			Expression string `json:"expression"`
+		} `json:"licenses"`
+	} `json:"components"`
+}
+
+func cycloneDXPackages(raw []byte) ([]Package, error) {
+	var document cycloneDXDocument
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, c := range document.Components {
+		pkg := Package{
+			Name:    c.Name,
+			Version: c.Version,
+			Type:    purlType(c.PURL),
+		}
+		if pkg.Type == "" {
+			pkg.Type = c.Type
+		}
+		var licenses []string
+		for _, l := range c.Licenses {
+			switch {
+			case l.Expression != "":
+				licenses = append(licenses, l.Expression)
+			case l.License.ID != "":
+				licenses = append(licenses, l.License.ID)
+			case l.License.Name != "":
+				licenses = append(licenses, l.License.Name)
+			}
+		}
+		pkg.License = strings.Join(licenses, " OR ")
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// purlType returns the type of a package URL, like "deb" for "pkg:deb/debian/bash@5.1"
+func purlType(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+	t := strings.TrimPrefix(purl, "pkg:")
+	if i := strings.Index(t, "/"); i >= 0 {
+		return t[:i]
+	}
+	return ""
+}
+
+// summarizeLicenses counts the packages per license, the most used first
+func summarizeLicenses(packages []Package) []License {
+	counts := map[string]int{}
+	for _, p := range packages {
+		license := p.License
+		if license == "" {
+			license = noAssertion
+		}
+		counts[license]++
+	}
+	licenses := make([]License, 0, len(counts))
+	for license, count := range counts {
+		licenses = append(licenses, License{License: license, Packages: count})
+	}
+	sort.Slice(licenses, func(i, j int) bool {
+		if licenses[i].Packages != licenses[j].Packages {
+			return licenses[i].Packages > licenses[j].Packages
+		}
+		return licenses[i].License < licenses[j].License
+	})
+	return licenses
+}
+
+func printPackages(out io.Writer, values interface{}) error {
+	packages := values.([]Package)
+	tw := tabwriter.New(out, "    ")
+	for _, header := range []string{"NAME", "VERSION", "TYPE", "LICENSE"} {
+		tw.Column(ansi.Header(header), len(header))
+	}
+	tw.Line()
+	for _, p := range packages {
+		tw.Column(p.Name, len(p.Name))
+		tw.Column(p.Version, len(p.Version))
+		tw.Column(p.Type, len(p.Type))
+		tw.Column(p.License, len(p.License))
+		tw.Line()
+	}
+	return tw.Flush()
+}
+
+func printLicenses(out io.Writer, values interface{}) error {
+	licenses := values.([]License)
+	tw := tabwriter.New(out, "    ")
+	for _, header := range []string{"LICENSE", "PACKAGES"} {
+		tw.Column(ansi.Header(header), len(header))
+	}
+	tw.Line()
+	for _, l := range licenses {
+		tw.Column(l.License, len(l.License))
+		count := fmt.Sprint(l.Packages)
+		tw.Column(count, len(count))
+		tw.Line()
+	}
+	return tw.Flush()
+}
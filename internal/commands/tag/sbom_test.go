@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestSbomPackages(t *testing.T) {
+	statements := []Statement{
+		{
+			PredicateType: "https://spdx.dev/Document",
+			Predicate: []byte(`{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "bash", "versionInfo": "5.1-2", "licenseConcluded": "NOASSERTION", "licenseDeclared": "GPL-3.0-or-later",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/debian/bash@5.1-2"}]},
+    {"name": "zlib", "versionInfo": "1.2.13", "licenseConcluded": "Zlib",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/debian/zlib@1.2.13"}]},
+    {"name": "unknown", "versionInfo": "0.1", "licenseConcluded": "NOASSERTION", "licenseDeclared": "NOASSERTION"}
+  ]
+}`),
+		},
+		{
+			PredicateType: "https://cyclonedx.org/bom/v1.4",
+			Predicate: []byte(`{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"name": "github.com/spf13/cobra", "version": "v1.7.0", "type": "library", "purl": "pkg:golang/github.com/spf13/cobra@v1.7.0",
+     "licenses": [{"license": {"id": "Apache-2.0"}}]},
+    {"name": "zlib", "version": "1.2.13", "type": "library", "purl": "pkg:deb/debian/zlib@1.2.13",
+     "licenses": [{"license": {"id": "Zlib"}}]},
+    {"name": "app", "version": "1.0", "type": "application", "licenses": [{"expression": "MIT OR Apache-2.0"}]}
+  ]
+}`),
+		},
+	}
+	packages, err := sbomPackages(statements)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, packages, []Package{
+		{Name: "app", Version: "1.0", Type: "application", License: "MIT OR Apache-2.0"},
+		{Name: "bash", Version: "5.1-2", Type: "deb", License: "GPL-3.0-or-later"},
+		{Name: "github.com/spf13/cobra", Version: "v1.7.0", Type: "golang", License: "Apache-2.0"},
+		{Name: "unknown", Version: "0.1"},
+		{Name: "zlib", Version: "1.2.13", Type: "deb", License: "Zlib"},
+	})
+
+	out := bytes.NewBuffer(nil)
+	assert.NilError(t, printPackages(out, packages))
+	golden.Assert(t, out.String(), "sbom-packages.golden")
+
+	assert.DeepEqual(t, summarizeLicenses(packages), []License{
+		{License: "Apache-2.0", Packages: 1},
+		{License: "GPL-3.0-or-later", Packages: 1},
+		{License: "MIT OR Apache-2.0", Packages: 1},
+		{License: "NOASSERTION", Packages: 1},
+		{License: "Zlib", Packages: 1},
+	})
+}
+
+func TestIsSbomPredicate(t *testing.T) {
+	assert.Assert(t, isSbomPredicate("https://spdx.dev/Document"))
+	assert.Assert(t, isSbomPredicate("https://cyclonedx.org/bom/v1.4"))
+	assert.Assert(t, !isSbomPredicate("https://slsa.dev/provenance/v0.2"))
+}
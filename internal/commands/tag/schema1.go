@@ -0,0 +1,202 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/docker/hub-tool/internal/ansi"
+)
+
+const (
+	// mediaTypeDockerSchema1Unsigned is the media type of a schema1 manifest without signatures
+	mediaTypeDockerSchema1Unsigned = "application/vnd.docker.distribution.manifest.v1+json"
+	// mediaTypeDockerLayer is used for schema1 layers, which have no media type
+	mediaTypeDockerLayer = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// Schema1Image is an image described by a legacy Docker schema1 manifest,
+// converted to a manifest and a config to be displayed like other images
+type Schema1Image struct {
+	Image
+	Tag        string
+	Signatures []Schema1Signature
+}
+
+// Schema1Signature is the JWS signature of a signed schema1 manifest
+type Schema1Signature struct {
+	KeyID     string
+	Algorithm string
+}
+
+type schema1Manifest struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Name          string `json:"name"`
+	Tag           string `json:"tag"`
+	Architecture  string `json:"architecture"`
+	FSLayers      []struct {
+		BlobSum string `json:"blobSum"`
+	} `json:"fsLayers"`
+	History []struct {
+		V1Compatibility string `json:"v1Compatibility"`
+	} `json:"history"`
+	Signatures []struct {
+		Header struct {
+			JWK struct {
+				KeyID string `json:"kid"`
+			} `json:"jwk"`
+			Algorithm string `json:"alg"`
+		} `json:"header"`
+	} `json:"signatures,omitempty"`
+}
+
+type schema1V1Compatibility struct {
+	Created         *time.Time           `json:"created,omitempty"`
+	Author          string               `json:"author,omitempty"`
+	OS              string               `json:"os,omitempty"`
+	Architecture    string               `json:"architecture,omitempty"`
+	Config          *ocispec.ImageConfig `json:"config,omitempty"`
+	ContainerConfig struct {
+		Cmd []string `json:"Cmd,omitempty"`
+	} `json:"container_config,omitempty"`
+	Size      int64 `json:"Size,omitempty"`
+	ThrowAway bool  `json:"throwaway,omitempty"`
+}
+
+func formatSchema1(streams command.Streams, format string, raw []byte, descriptor ocispec.Descriptor, name string) error {
+	image, err := readSchema1(raw, descriptor, name)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "raw":
+		_, err := fmt.Fprintf(streams.Out(), "%s", raw) // avoid newline to keep digest
+		return err
+	case "json":
+		buf, err := json.MarshalIndent(image, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(streams.Out(), string(buf))
+		return err
+	case "":
+		return printSchema1(streams.Out(), image)
+	default:
+		return printTemplate(streams.Out(), format, image)
+	}
+}
+
+// readSchema1 converts a schema1 manifest, whose layers and history are
+// listed from the top most layer, into an image
+func readSchema1(raw []byte, descriptor ocispec.Descriptor, name string) (*Schema1Image, error) {
+	var manifest schema1Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, err
+	}
+	if len(manifest.FSLayers) != len(manifest.History) {
+		return nil, fmt.Errorf("invalid schema1 manifest: %d layers for %d history entries", len(manifest.FSLayers), len(manifest.History))
+	}
+
+	image := &Schema1Image{
+		Image: Image{
+			Name:       name,
+			Descriptor: descriptor,
+		},
+		Tag: manifest.Tag,
+	}
+	image.Config.Architecture = manifest.Architecture
+	for i := len(manifest.History) - 1; i >= 0; i-- {
+		var v1 schema1V1Compatibility
+		if err := json.Unmarshal([]byte(manifest.History[i].V1Compatibility), &v1); err != nil {
+			return nil, fmt.Errorf("invalid schema1 manifest history: %s", err)
+		}
+		// The top most entry holds the configuration of the image
+		if i == 0 {
+			image.Config.Created = v1.Created
+			image.Config.Author = v1.Author
+			image.Config.OS = v1.OS
+			if v1.Architecture != "" {
+				image.Config.Architecture = v1.Architecture
+			}
+			if v1.Config != nil {
+				image.Config.Config = *v1.Config
+			}
+		}
+		image.Config.History = append(image.Config.History, ocispec.History{
+			Created:    v1.Created,
+			CreatedBy:  strings.Join(v1.ContainerConfig.Cmd, " "),
+			Author:     v1.Author,
+			EmptyLayer: v1.ThrowAway,
+		})
+		if !v1.ThrowAway {
+			image.Manifest.Layers = append(image.Manifest.Layers, ocispec.Descriptor{
+				MediaType: mediaTypeDockerLayer,
+				Digest:    digest.Digest(manifest.FSLayers[i].BlobSum),
+				Size:      v1.Size,
+			})
+		}
+	}
+	for _, s := range manifest.Signatures {
+		image.Signatures = append(image.Signatures, Schema1Signature{
+			KeyID:     s.Header.JWK.KeyID,
+			Algorithm: s.Header.Algorithm,
+		})
+	}
+	return image, nil
+}
+
+func printSchema1(out io.Writer, image *Schema1Image) error {
+	fmt.Fprintf(out, ansi.Title("Manifest:")+"\n")
+	fmt.Fprintf(out, ansi.Key("Name:")+"\t\t%s\n", image.Name)
+	fmt.Fprintf(out, ansi.Key("MediaType:")+"\t%s\n", image.Descriptor.MediaType)
+	fmt.Fprintf(out, ansi.Key("Digest:")+"\t\t%s\n", image.Descriptor.Digest)
+	fmt.Fprintf(out, ansi.Key("Schema:")+"\t\t%s\n", ansi.Warn("Docker schema1 (deprecated)"))
+	if image.Tag != "" {
+		fmt.Fprintf(out, ansi.Key("Tag:")+"\t\t%s\n", image.Tag)
+	}
+	if image.Descriptor.MediaType == images.MediaTypeDockerSchema1Manifest || len(image.Signatures) > 0 {
+		fmt.Fprintf(out, ansi.Key("Signatures:")+"\n")
+		for _, s := range image.Signatures {
+			fmt.Fprintf(out, "    %s (%s)\n", s.KeyID, s.Algorithm)
+		}
+	}
+	if image.Config.Architecture != "" {
+		fmt.Fprintf(out, ansi.Key("Os/Arch:")+"\t%s/%s\n", image.Config.OS, image.Config.Architecture)
+	}
+	if image.Config.Author != "" {
+		fmt.Fprintf(out, ansi.Key("Author:")+"\t%s\n", image.Config.Author)
+	}
+	if image.Config.Created != nil {
+		fmt.Fprintf(out, ansi.Key("Created:")+"\t%s ago\n", units.HumanDuration(time.Since(*image.Config.Created)))
+	}
+	fmt.Fprintf(out, "\n")
+
+	fmt.Fprintf(out, ansi.Title("Config:")+"\n")
+	printImageConfig(out, image.Config.Config)
+
+	return printLayers(out, &image.Image)
+}
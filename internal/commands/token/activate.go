@@ -17,14 +17,15 @@
 package token
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/metrics"
 	"github.com/docker/hub-tool/pkg/hub"
 )
@@ -34,10 +35,11 @@ const (
 )
 
 func newActivateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts bulkOptions
 	cmd := &cobra.Command{
-		Use:                   activateName + " TOKEN_UUID",
-		Short:                 "Activate a Personal Access Token",
-		Args:                  cli.ExactArgs(1),
+		Use:                   activateName + " [OPTIONS] [TOKEN_UUID...]",
+		Short:                 "Activate Personal Access Tokens",
+		ValidArgsFunction:     completion.Tokens(hubClient),
 		DisableFlagsInUseLine: true,
 		Annotations: map[string]string{
 			"sudo": "true",
@@ -45,21 +47,42 @@ func newActivateCmd(streams command.Streams, hubClient *hub.Client, parent strin
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, activateName)
 		},
-		RunE: func(_ *cobra.Command, args []string) error {
-			return runActivate(streams, hubClient, args[0])
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runActivate(cmd.Context(), streams, hubClient, opts, args)
 		},
 	}
+	opts.addBulkFlags(cmd.Flags(), "Activate")
 	return cmd
 }
 
-func runActivate(streams command.Streams, hubClient *hub.Client, tokenUUID string) error {
-	u, err := uuid.Parse(tokenUUID)
+func runActivate(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts bulkOptions, args []string) error {
+	if opts.single(args) {
+		u, err := uuid.Parse(args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := hubClient.UpdateToken(u.String(), "", true); err != nil {
+			return err
+		}
+		fmt.Fprintf(streams.Out(), ansi.Emphasise("%s is active\n"), u.String())
+		return nil
+	}
+
+	tokens, err := selectTokens(hubClient, args, opts.filters)
 	if err != nil {
 		return err
 	}
-	if _, err := hubClient.UpdateToken(u.String(), "", true); err != nil {
-		return err
+	if len(tokens) == 0 {
+		fmt.Fprintln(streams.Out(), ansi.Info("No token to activate"))
+		return nil
 	}
-	fmt.Fprintf(streams.Out(), ansi.Emphasise("%s is active\n"), u.String())
-	return nil
+	if !opts.force {
+		if err := confirmBulk(ctx, streams, activateName, tokens); err != nil {
+			return err
+		}
+	}
+	return runBulk(streams.Out(), streams.Err(), activateName, tokens, func(t hub.Token) error {
+		_, err := hubClient.UpdateToken(t.UUID.String(), "", true)
+		return err
+	}, "%s is active")
 }
@@ -0,0 +1,270 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
+	"github.com/docker/hub-tool/internal/format"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/prompt"
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	auditName = "audit"
+)
+
+type auditOptions struct {
+	format.Option
+	unusedFor     string
+	createdBefore string
+	deactivate    bool
+	force         bool
+}
+
+// auditReport is the result of an audit, printed with all its fields by the json and yaml
+// formats so that it can be kept as compliance evidence
+type auditReport struct {
+	GeneratedAt   time.Time      `json:"generated_at"`
+	Account       string         `json:"account"`
+	UnusedFor     string         `json:"unused_for,omitempty"`
+	CreatedBefore *time.Time     `json:"created_before,omitempty"`
+	Audited       int            `json:"audited"`
+	Findings      []auditFinding `json:"findings"`
+}
+
+// auditFinding is an active token which should be retired
+type auditFinding struct {
+	Token       schema.Token `json:"token"`
+	Reasons     []string     `json:"reasons"`
+	Deactivated bool         `json:"deactivated"`
+	// Error is the failure to deactivate the token
+	Error string `json:"error,omitempty"`
+
+	token hub.Token
+}
+
+func newAuditCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts auditOptions
+	cmd := &cobra.Command{
+		Use:   auditName + " [OPTIONS]",
+		Short: "Report the stale Personal Access Tokens",
+		Long: `Report the active Personal Access Tokens which were not used for a while, or never used
+since their creation, and optionally the ones created a long time ago. With --deactivate,
+the reported tokens are deactivated once confirmed.`,
+		Args:                  cli.NoArgs,
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, auditName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(cmd.Context(), streams, hubClient, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.unusedFor, "unused-for", "90d", "Report the tokens not used for this duration, like 60d or 12w")
+	cmd.Flags().StringVar(&opts.createdBefore, "created-before", "", "Also report the tokens created before a date (2006-01-02) or a duration ago, like 1y")
+	cmd.Flags().BoolVar(&opts.deactivate, "deactivate", false, "Deactivate the reported tokens")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Deactivate without asking for confirmation")
+	opts.AddFormatFlag(cmd.Flags())
+	return cmd
+}
+
+func runAudit(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts auditOptions) error {
+	now := time.Now()
+	report := auditReport{
+		GeneratedAt: now.UTC(),
+		Account:     hubClient.AuthConfig.Username,
+		UnusedFor:   opts.unusedFor,
+	}
+	var unusedSince, createdBefore time.Time
+	if opts.unusedFor != "" {
+		d, err := filter.ParseDuration(opts.unusedFor)
+		if err != nil {
+			return errdef.Validation(err)
+		}
+		unusedSince = now.Add(-d)
+	}
+	if opts.createdBefore != "" {
+		t, err := filter.ParseTime(opts.createdBefore, now)
+		if err != nil {
+			return errdef.Validation(err)
+		}
+		createdBefore = t
+		report.CreatedBefore = &t
+	}
+
+	if err := hubClient.Update(hub.WithAllElements()); err != nil {
+		return err
+	}
+	tokens, _, err := hubClient.GetTokens()
+	if err != nil {
+		return err
+	}
+	report.Findings = []auditFinding{}
+	for _, token := range tokens {
+		if !token.IsActive {
+			continue
+		}
+		report.Audited++
+		if reasons := auditToken(token, unusedSince, createdBefore, now); len(reasons) > 0 {
+			report.Findings = append(report.Findings, auditFinding{
+				Token:   schema.NewToken(token),
+				Reasons: reasons,
+				token:   token,
+			})
+		}
+	}
+
+	if !opts.deactivate || len(report.Findings) == 0 {
+		return opts.PrintTable(streams.Out(), report, printAuditReport, auditRecords)
+	}
+	if opts.force {
+		// Without confirmation, the report records whether each token was deactivated
+		deactivateErr := deactivateFindings(hubClient, report.Findings)
+		if err := opts.PrintTable(streams.Out(), report, printAuditReport, auditRecords); err != nil {
+			return err
+		}
+		return deactivateErr
+	}
+	if err := opts.PrintTable(streams.Out(), report, printAuditReport, auditRecords); err != nil {
+		return err
+	}
+	if err := confirmDeactivate(ctx, streams, len(report.Findings)); err != nil {
+		return err
+	}
+	deactivateErr := deactivateFindings(hubClient, report.Findings)
+	for _, finding := range report.Findings {
+		if finding.Error != "" {
+			fmt.Fprintf(streams.Err(), "%s: %s\n", finding.token.UUID, finding.Error)
+			continue
+		}
+		fmt.Fprintf(streams.Out(), ansi.Emphasise("%s is inactive\n"), finding.token.UUID)
+	}
+	return deactivateErr
+}
+
+// auditToken returns why the token should be retired: a token is stale if it wasn't used
+// since unusedSince, a token never used only once it was created before unusedSince
+func auditToken(token hub.Token, unusedSince, createdBefore, now time.Time) []string {
+	var reasons []string
+	if !unusedSince.IsZero() {
+		if token.LastUsed.IsZero() && token.CreatedAt.Before(unusedSince) {
+			reasons = append(reasons, "never used")
+		} else if !token.LastUsed.IsZero() && token.LastUsed.Before(unusedSince) {
+			reasons = append(reasons, fmt.Sprintf("unused for %s", units.HumanDuration(now.Sub(token.LastUsed))))
+		}
+	}
+	if !createdBefore.IsZero() && token.CreatedAt.Before(createdBefore) {
+		reasons = append(reasons, fmt.Sprintf("created %s ago", units.HumanDuration(now.Sub(token.CreatedAt))))
+	}
+	return reasons
+}
+
+func confirmDeactivate(ctx context.Context, streams command.Streams, count int) error {
+	confirmed, err := prompt.Confirm(ctx, streams.In(), streams.Out(), fmt.Sprintf("Are you sure you want to deactivate these %d tokens?", count))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("deactivation aborted")
+	}
+	return nil
+}
+
+// deactivateFindings deactivates all the tokens, recording the failures in the report
+func deactivateFindings(hubClient *hub.Client, findings []auditFinding) error {
+	failed := 0
+	for i := range findings {
+		token := findings[i].token
+		if _, err := hubClient.UpdateToken(token.UUID.String(), "", false); err != nil {
+			findings[i].Error = err.Error()
+			failed++
+			continue
+		}
+		findings[i].Deactivated = true
+		findings[i].Token.IsActive = false
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to deactivate %d of %d tokens", failed, len(findings))
+	}
+	return nil
+}
+
+func printAuditReport(out io.Writer, value interface{}) error {
+	report := value.(auditReport)
+	fmt.Fprintln(out, ansi.Title(fmt.Sprintf("Audited %d active tokens of %s on %s, %d stale", report.Audited, report.Account, report.GeneratedAt.Format(time.RFC3339), len(report.Findings))))
+	if len(report.Findings) == 0 {
+		return nil
+	}
+	header, rows := auditRecords(report)
+	tw := tabwriter.New(out, "    ")
+	for _, column := range header {
+		tw.Column(ansi.Header(column), len(column))
+	}
+	tw.Line()
+	for _, row := range rows {
+		for _, value := range row {
+			tw.Column(value, len(value))
+		}
+		tw.Line()
+	}
+	return tw.Flush()
+}
+
+// auditRecords returns the table of the stale tokens, printed by the csv and tsv formats
+func auditRecords(value interface{}) ([]string, [][]string) {
+	report := value.(auditReport)
+	header := []string{"DESCRIPTION", "UUID", "LAST USED", "CREATED", "REASONS", "STATUS"}
+	var rows [][]string
+	for _, finding := range report.Findings {
+		status := "active"
+		switch {
+		case finding.Deactivated:
+			status = "deactivated"
+		case finding.Error != "":
+			status = "failed: " + finding.Error
+		}
+		rows = append(rows, []string{
+			finding.token.Description,
+			finding.token.UUID.String(),
+			getLastUsed(finding.token.LastUsed),
+			finding.token.CreatedAt.UTC().Format(time.RFC3339),
+			strings.Join(finding.Reasons, ", "),
+			status,
+		})
+	}
+	return header, rows
+}
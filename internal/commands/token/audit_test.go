@@ -0,0 +1,165 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/cli/streams"
+	"github.com/google/uuid"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/schema"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func TestAuditToken(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	unusedSince := now.Add(-60 * 24 * time.Hour)
+	createdBefore := now.Add(-365 * 24 * time.Hour)
+	testCases := []struct {
+		name     string
+		token    hub.Token
+		expected []string
+	}{
+		{
+			name:  "recently used",
+			token: hub.Token{CreatedAt: now.Add(-400 * 24 * time.Hour), LastUsed: now.Add(-time.Hour)},
+			expected: []string{
+				"created 13 months ago",
+			},
+		},
+		{
+			name:  "never used but recent",
+			token: hub.Token{CreatedAt: now.Add(-24 * time.Hour)},
+		},
+		{
+			name:     "never used",
+			token:    hub.Token{CreatedAt: now.Add(-90 * 24 * time.Hour)},
+			expected: []string{"never used"},
+		},
+		{
+			name:     "idle",
+			token:    hub.Token{CreatedAt: now.Add(-200 * 24 * time.Hour), LastUsed: now.Add(-100 * 24 * time.Hour)},
+			expected: []string{"unused for 3 months"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.DeepEqual(t, auditToken(tc.token, unusedSince, createdBefore, now), tc.expected)
+		})
+	}
+}
+
+func TestAuditRecords(t *testing.T) {
+	token := hub.Token{
+		UUID:        uuid.MustParse("2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a"),
+		Description: "ci",
+		CreatedAt:   time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		IsActive:    true,
+	}
+	report := auditReport{Findings: []auditFinding{
+		{Token: schema.NewToken(token), Reasons: []string{"never used"}, Deactivated: true, token: token},
+		{Token: schema.NewToken(token), Reasons: []string{"never used"}, Error: "operation not permitted", token: token},
+	}}
+	header, rows := auditRecords(report)
+	assert.DeepEqual(t, header, []string{"DESCRIPTION", "UUID", "LAST USED", "CREATED", "REASONS", "STATUS"})
+	assert.DeepEqual(t, rows, [][]string{
+		{"ci", "2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a", "Never", "2020-01-02T03:04:05Z", "never used", "deactivated"},
+		{"ci", "2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a", "Never", "2020-01-02T03:04:05Z", "never used", "failed: operation not permitted"},
+	})
+}
+
+type testStreams struct {
+	in  *streams.In
+	out *streams.Out
+	err io.Writer
+}
+
+func (s testStreams) In() *streams.In   { return s.in }
+func (s testStreams) Out() *streams.Out { return s.out }
+func (s testStreams) Err() io.Writer    { return s.err }
+
+func TestAuditPrintsTheReportBeforeConfirmingTheDeactivation(t *testing.T) {
+	var deactivated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintf(w, `{"count": 1, "results": [
+				{"uuid": %q, "token_label": "ci-build", "is_active": true, "created_at": "2020-01-02T03:04:05Z"}
+			]}`, ciBuildUUID)
+		case http.MethodPatch:
+			deactivated = append(deactivated, r.URL.Path)
+			fmt.Fprintf(w, `{"uuid": %q, "token_label": "ci-build", "is_active": false, "created_at": "2020-01-02T03:04:05Z"}`, ciBuildUUID)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("DOCKER_HUB_API_URL", server.URL)
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectedErr string
+		deactivated []string
+	}{
+		{
+			name:        "confirmed",
+			input:       "y\n",
+			deactivated: []string{"/v2/api_tokens/" + ciBuildUUID},
+		},
+		{
+			name:        "aborted",
+			input:       "n\n",
+			expectedErr: "deactivation aborted",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deactivated = nil
+			hubClient, err := hub.NewClient()
+			assert.NilError(t, err)
+			out := bytes.NewBuffer(nil)
+			s := testStreams{
+				in:  streams.NewIn(io.NopCloser(strings.NewReader(tc.input))),
+				out: streams.NewOut(out),
+				err: io.Discard,
+			}
+			opts := auditOptions{unusedFor: "90d", deactivate: true}
+			err = runAudit(context.Background(), s, hubClient, opts)
+			if tc.expectedErr != "" {
+				assert.Error(t, err, tc.expectedErr)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.DeepEqual(t, deactivated, tc.deactivated)
+
+			report := strings.Index(out.String(), ciBuildUUID)
+			prompt := strings.Index(out.String(), "Are you sure you want to deactivate these 1 tokens?")
+			assert.Assert(t, report >= 0)
+			assert.Assert(t, prompt > report, out.String())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/prompt"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// bulkConcurrency is the number of tokens updated or removed at the same time
+const bulkConcurrency = 8
+
+// bulkOptions select several tokens by UUID and filters
+type bulkOptions struct {
+	filters []string
+	force   bool
+}
+
+func (o *bulkOptions) addBulkFlags(flags *pflag.FlagSet, action string) {
+	flags.StringArrayVar(&o.filters, "filter", nil,
+		fmt.Sprintf("%s the tokens matching the filter, on description, active, scope, created, last-used or expires (e.g.: --filter description=ci-*)", action))
+	flags.BoolVarP(&o.force, "force", "f", false, "Do not prompt for confirmation")
+}
+
+// single returns true if only one token is given without any filter, keeping the
+// behavior of the commands before they supported several tokens
+func (o *bulkOptions) single(args []string) bool {
+	return len(args) == 1 && len(o.filters) == 0
+}
+
+// selectTokens returns the tokens given by UUID, or all the tokens if none is given,
+// which match the filters
+func selectTokens(hubClient *hub.Client, args []string, exprs []string) ([]hub.Token, error) {
+	if len(args) == 0 && len(exprs) == 0 {
+		return nil, errdef.Validation(errors.New("requires at least one token UUID or a --filter"))
+	}
+	filters, err := parseTokenFilters(exprs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var uuids []uuid.UUID
+	for _, arg := range args {
+		u, err := uuid.Parse(arg)
+		if err != nil {
+			return nil, errdef.Validation(fmt.Errorf("invalid token UUID %q: %s", arg, err))
+		}
+		uuids = append(uuids, u)
+	}
+
+	if err := hubClient.Update(hub.WithAllElements()); err != nil {
+		return nil, err
+	}
+	tokens, _, err := hubClient.GetTokens()
+	if err != nil {
+		return nil, err
+	}
+	if len(uuids) > 0 {
+		byUUID := make(map[uuid.UUID]hub.Token, len(tokens))
+		for _, t := range tokens {
+			byUUID[t.UUID] = t
+		}
+		tokens = nil
+		for _, u := range uuids {
+			t, ok := byUUID[u]
+			if !ok {
+				return nil, fmt.Errorf("token %s: %w", u, errdef.ErrNotFound)
+			}
+			tokens = append(tokens, t)
+		}
+	}
+	return filters.apply(tokens), nil
+}
+
+// confirmBulk lists the tokens and asks for the confirmation of the action on all of them
+func confirmBulk(ctx context.Context, streams command.Streams, action string, tokens []hub.Token) error {
+	fmt.Fprintln(streams.Out(), ansi.Warn(fmt.Sprintf("You are about to %s %d tokens:", action, len(tokens))))
+	for _, t := range tokens {
+		fmt.Fprintf(streams.Out(), "    %s    %s\n", t.UUID, t.Description)
+	}
+	confirmed, err := prompt.Confirm(ctx, streams.In(), streams.Out(), fmt.Sprintf("Are you sure you want to %s these %d tokens?", action, len(tokens)))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("%s aborted", action)
+	}
+	return nil
+}
+
+// runBulk calls the operation on all the tokens concurrently, printing the outcome for
+// each token in order. Failing for a token doesn't stop the operation on the others.
+func runBulk(out, errOut io.Writer, action string, tokens []hub.Token, operation func(hub.Token) error, done string) error {
+	errs := make([]error, len(tokens))
+	var eg errgroup.Group
+	eg.SetLimit(bulkConcurrency)
+	for i, t := range tokens {
+		i, t := i, t
+		eg.Go(func() error {
+			errs[i] = operation(t)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	failed := 0
+	for i, t := range tokens {
+		if errs[i] != nil {
+			failed++
+			fmt.Fprintf(errOut, "%s: %s\n", t.UUID, errs[i])
+			continue
+		}
+		fmt.Fprintf(out, ansi.Emphasise(done+"\n"), t.UUID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d tokens", action, failed, len(tokens))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	ciBuildUUID  = "11111111-1111-4111-8111-111111111111"
+	ciDeployUUID = "22222222-2222-4222-8222-222222222222"
+	laptopUUID   = "33333333-3333-4333-8333-333333333333"
+)
+
+// newBulkServer fakes the Hub tokens API, failing the deletion of the laptop token
+func newBulkServer(t *testing.T) (*hub.Client, func() []string) {
+	var (
+		lock    sync.Mutex
+		deleted []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintf(w, `{"count": 3, "results": [
+				{"uuid": %q, "token_label": "ci-build", "is_active": true},
+				{"uuid": %q, "token_label": "ci-deploy", "is_active": true},
+				{"uuid": %q, "token_label": "laptop", "is_active": false}
+			]}`, ciBuildUUID, ciDeployUUID, laptopUUID)
+		case http.MethodDelete:
+			if r.URL.Path == "/v2/api_tokens/"+laptopUUID {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			lock.Lock()
+			deleted = append(deleted, r.URL.Path)
+			lock.Unlock()
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("DOCKER_HUB_API_URL", server.URL)
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+	hubClient, err := hub.NewClient()
+	assert.NilError(t, err)
+	return hubClient, func() []string {
+		sort.Strings(deleted)
+		return deleted
+	}
+}
+
+func TestSelectTokens(t *testing.T) {
+	hubClient, _ := newBulkServer(t)
+
+	selected, err := selectTokens(hubClient, nil, []string{"description=ci-*"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, descriptions(selected), []string{"ci-build", "ci-deploy"})
+
+	selected, err = selectTokens(hubClient, []string{laptopUUID, ciBuildUUID}, []string{"active=true"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, descriptions(selected), []string{"ci-build"})
+
+	_, err = selectTokens(hubClient, []string{"44444444-4444-4444-8444-444444444444"}, nil)
+	assert.Error(t, err, "token 44444444-4444-4444-8444-444444444444: not found")
+	assert.Equal(t, errdef.ExitCode(err), 5)
+
+	_, err = selectTokens(hubClient, nil, nil)
+	assert.Assert(t, errdef.IsValidation(err))
+}
+
+func TestRunBulkContinuesAfterAFailure(t *testing.T) {
+	hubClient, deleted := newBulkServer(t)
+	selected, err := selectTokens(hubClient, []string{ciBuildUUID, laptopUUID, ciDeployUUID}, nil)
+	assert.NilError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	errOut := bytes.NewBuffer(nil)
+	err = runBulk(out, errOut, "delete", selected, func(t hub.Token) error {
+		return hubClient.RemoveToken(t.UUID.String())
+	}, "Access token deleted %s")
+	assert.Error(t, err, "failed to delete 1 of 3 tokens")
+	assert.Equal(t, out.String(), "Access token deleted "+ciBuildUUID+"\nAccess token deleted "+ciDeployUUID+"\n")
+	assert.Equal(t, errOut.String(), laptopUUID+": operation not permitted\n")
+	assert.DeepEqual(t, deleted(), []string{"/v2/api_tokens/" + ciBuildUUID, "/v2/api_tokens/" + ciDeployUUID})
+}
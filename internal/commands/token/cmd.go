@@ -46,6 +46,8 @@ func NewTokenCmd(streams command.Streams, hubClient *hub.Client) *cobra.Command
 		newActivateCmd(streams, hubClient, tokenName),
 		newDeactivateCmd(streams, hubClient, tokenName),
 		newRmCmd(streams, hubClient, tokenName),
+		newRotateCmd(streams, hubClient, tokenName),
+		newAuditCmd(streams, hubClient, tokenName),
 	)
 	return cmd
 }
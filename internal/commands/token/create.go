@@ -17,16 +17,24 @@
 package token
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/config"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -38,6 +46,11 @@ type createOptions struct {
 	format.Option
 	description string
 	quiet       bool
+	scope       string
+	expires     string
+	outputAs    string
+	namespace   string
+	name        string
 }
 
 func newCreateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
@@ -53,26 +66,92 @@ func newCreateCmd(streams command.Streams, hubClient *hub.Client, parent string)
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, createName)
 		},
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.outputAs != "" && (opts.quiet || cmd.Flags().Changed("format")) {
+				return errdef.Validation(errors.New("--output-as can't be used with --quiet or --format"))
+			}
 			return runCreate(streams, hubClient, opts)
 		},
 	}
 	opts.AddFormatFlag(cmd.Flags())
 	cmd.Flags().StringVar(&opts.description, "description", "", "Set token's description")
 	cmd.Flags().BoolVar(&opts.quiet, "quiet", false, "Display only created token")
+	cmd.Flags().StringVar(&opts.scope, "scope", "", fmt.Sprintf("Permissions of the token, one of %s (default all the permissions)", strings.Join(hub.Scopes, ", ")))
+	cmd.Flags().StringVar(&opts.expires, "expires", "", "Expire the token after a duration, like 12h, 30d or 1y (default never)")
+	cmd.Flags().StringVar(&opts.outputAs, "output-as", "", fmt.Sprintf(`Export the token instead of printing it, "%s" prints a Kubernetes image pull secret manifest, "%s" stores it in the docker config.json`, outputAsK8sSecret, outputAsDockerConfig))
+	cmd.Flags().StringVar(&opts.namespace, "namespace", "", "Namespace of the Kubernetes secret")
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name of the Kubernetes secret")
+	_ = cmd.RegisterFlagCompletionFunc("scope", cobra.FixedCompletions(hub.Scopes, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("output-as", cobra.FixedCompletions(outputAsTypes, cobra.ShellCompDirectiveNoFileComp))
 	return cmd
 }
 
 func runCreate(streams command.Streams, hubClient *hub.Client, opts createOptions) error {
-	token, err := hubClient.CreateToken(opts.description)
+	scopes, expiresAt, err := parseScopeAndExpiration(opts.scope, opts.expires, time.Now())
 	if err != nil {
 		return err
 	}
+	if err := validateOutputAs(opts.outputAs, opts.name, opts.namespace); err != nil {
+		return err
+	}
+	// The token must never be printed to a terminal when exporting it
+	if opts.outputAs == outputAsK8sSecret && streams.Out().IsTerminal() {
+		return errdef.Validation(errors.New("refusing to print the Kubernetes secret to a terminal, redirect the output to a file or to kubectl apply -f -"))
+	}
+	token, err := hubClient.CreateTokenWithOptions(opts.description, scopes, expiresAt)
+	if err != nil {
+		return err
+	}
+	if opts.outputAs != "" {
+		return exportToken(streams, hubClient, opts, token)
+	}
 	if opts.quiet {
 		fmt.Fprintln(streams.Out(), token.Token)
 		return nil
 	}
-	return opts.Print(streams.Out(), token, printCreatedToken(hubClient))
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindToken, Value: token, Item: schema.NewToken(*token)}, printCreatedToken(hubClient))
+}
+
+// exportToken writes the token to the Kubernetes secret or the docker config.
+// The token is removed if the export fails, as it can't be retrieved again.
+func exportToken(streams command.Streams, hubClient *hub.Client, opts createOptions, token *hub.Token) error {
+	var err error
+	switch opts.outputAs {
+	case outputAsK8sSecret:
+		err = writeK8sSecret(streams.Out(), opts.name, opts.namespace, hub.RegistryServer(), hubClient.AuthConfig.Username, token.Token)
+	case outputAsDockerConfig:
+		_, err = storeDockerConfig(config.Dir(), hubClient.AuthConfig.Username, token)
+	}
+	if err != nil {
+		if rmErr := hubClient.RemoveToken(token.UUID.String()); rmErr != nil {
+			return fmt.Errorf("%w (the token %s could not be removed: %s)", err, token.UUID, rmErr)
+		}
+		return fmt.Errorf("%w (the token %s was removed)", err, token.UUID)
+	}
+	if opts.outputAs == outputAsK8sSecret {
+		fmt.Fprintf(streams.Err(), ansi.Info("Created token %s\n"), token.UUID)
+		return nil
+	}
+	fmt.Fprintf(streams.Out(), ansi.Emphasise("Personal Access Token successfully created!")+"\nStored token %s as the %s credentials of %s\n",
+		token.UUID, hub.RegistryServer(), config.Dir())
+	return nil
+}
+
+// parseScopeAndExpiration validates the scope and converts the expiration duration to a date.
+// An empty scope gives all the permissions and an empty duration never expires.
+func parseScopeAndExpiration(scope, expires string, now time.Time) ([]string, time.Time, error) {
+	var scopes []string
+	if scope != "" {
+		if !slices.Contains(hub.Scopes, scope) {
+			return nil, time.Time{}, errdef.Validation(fmt.Errorf("invalid scope %q: should be one of %s", scope, strings.Join(hub.Scopes, ", ")))
+		}
+		scopes = []string{scope}
+	}
+	expiresAt, err := filter.ParseExpiration(expires, now)
+	if err != nil {
+		return nil, time.Time{}, errdef.Validation(err)
+	}
+	return scopes, expiresAt, nil
 }
 
 func printCreatedToken(hubClient *hub.Client) format.PrettyPrinter {
@@ -82,6 +161,8 @@ func printCreatedToken(hubClient *hub.Client) format.PrettyPrinter {
 
 When logging in from your Docker CLI client, use this token as a password.
 `+ansi.Header("Description:")+` %s
+`+ansi.Header("Scopes:")+` %s
+`+ansi.Header("Expires:")+` %s
 
 To use the access token from your Docker CLI client:
 1. Run: docker login --username %s
@@ -93,6 +174,8 @@ To use the access token from your Docker CLI client:
 It will not be stored and cannot be retrieved. Please be sure to save it now.
 `),
 			helper.Description,
+			getScopes(helper.Scopes),
+			getExpiresAt(helper.ExpiresAt),
 			hubClient.AuthConfig.Username,
 			ansi.Emphasise(helper.Token))
 		return nil
@@ -0,0 +1,54 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+func TestParseScopeAndExpiration(t *testing.T) {
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	scopes, expiresAt, err := parseScopeAndExpiration("repo:read", "30d", now)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, scopes, []string{"repo:read"})
+	assert.Equal(t, expiresAt, now.Add(30*24*time.Hour))
+
+	scopes, expiresAt, err = parseScopeAndExpiration("", "", now)
+	assert.NilError(t, err)
+	assert.Assert(t, scopes == nil)
+	assert.Assert(t, expiresAt.IsZero())
+
+	_, _, err = parseScopeAndExpiration("repo:delete", "", now)
+	assert.ErrorContains(t, err, `invalid scope "repo:delete": should be one of repo:read, repo:write, repo:admin`)
+	assert.Assert(t, errdef.IsValidation(err))
+
+	_, _, err = parseScopeAndExpiration("", "soon", now)
+	assert.ErrorContains(t, err, `invalid duration "soon"`)
+	assert.Assert(t, errdef.IsValidation(err))
+}
+
+func TestGetExpiresAt(t *testing.T) {
+	assert.Equal(t, getExpiresAt(time.Time{}), "Never")
+	assert.Equal(t, getExpiresAt(time.Now().Add(-49*time.Hour)), "Expired 2 days ago")
+	assert.Equal(t, getExpiresAt(time.Now().Add(30*24*time.Hour+time.Minute)), "In 4 weeks")
+}
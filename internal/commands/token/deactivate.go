@@ -17,14 +17,15 @@
 package token
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/metrics"
 	"github.com/docker/hub-tool/pkg/hub"
 )
@@ -34,10 +35,11 @@ const (
 )
 
 func newDeactivateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts bulkOptions
 	cmd := &cobra.Command{
-		Use:                   deactivateName + " TOKEN_UUID",
-		Short:                 "Deactivate a Personal Access Token",
-		Args:                  cli.ExactArgs(1),
+		Use:                   deactivateName + " [OPTIONS] [TOKEN_UUID...]",
+		Short:                 "Deactivate Personal Access Tokens",
+		ValidArgsFunction:     completion.Tokens(hubClient),
 		DisableFlagsInUseLine: true,
 		Annotations: map[string]string{
 			"sudo": "true",
@@ -45,21 +47,42 @@ func newDeactivateCmd(streams command.Streams, hubClient *hub.Client, parent str
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, deactivateName)
 		},
-		RunE: func(_ *cobra.Command, args []string) error {
-			return runDeactivate(streams, hubClient, args[0])
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeactivate(cmd.Context(), streams, hubClient, opts, args)
 		},
 	}
+	opts.addBulkFlags(cmd.Flags(), "Deactivate")
 	return cmd
 }
 
-func runDeactivate(streams command.Streams, hubClient *hub.Client, tokenUUID string) error {
-	u, err := uuid.Parse(tokenUUID)
+func runDeactivate(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts bulkOptions, args []string) error {
+	if opts.single(args) {
+		u, err := uuid.Parse(args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := hubClient.UpdateToken(u.String(), "", false); err != nil {
+			return err
+		}
+		fmt.Fprintf(streams.Out(), ansi.Emphasise("%s is inactive\n"), u.String())
+		return nil
+	}
+
+	tokens, err := selectTokens(hubClient, args, opts.filters)
 	if err != nil {
 		return err
 	}
-	if _, err := hubClient.UpdateToken(u.String(), "", false); err != nil {
-		return err
+	if len(tokens) == 0 {
+		fmt.Fprintln(streams.Out(), ansi.Info("No token to deactivate"))
+		return nil
 	}
-	fmt.Fprintf(streams.Out(), ansi.Emphasise("%s is inactive\n"), u.String())
-	return nil
+	if !opts.force {
+		if err := confirmBulk(ctx, streams, deactivateName, tokens); err != nil {
+			return err
+		}
+	}
+	return runBulk(streams.Out(), streams.Err(), deactivateName, tokens, func(t hub.Token) error {
+		_, err := hubClient.UpdateToken(t.UUID.String(), "", false)
+		return err
+	}, "%s is inactive")
 }
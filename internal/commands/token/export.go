@@ -0,0 +1,107 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+const (
+	outputAsK8sSecret    = "k8s-secret"
+	outputAsDockerConfig = "docker-config"
+)
+
+var outputAsTypes = []string{outputAsK8sSecret, outputAsDockerConfig}
+
+type dockerConfigAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+type k8sMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type k8sSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// validateOutputAs checks the export type and the Kubernetes secret name and namespace
+func validateOutputAs(outputAs, name, namespace string) error {
+	switch outputAs {
+	case "", outputAsDockerConfig:
+		if name != "" || namespace != "" {
+			return errdef.Validation(fmt.Errorf("--name and --namespace are only supported with --output-as %s", outputAsK8sSecret))
+		}
+		return nil
+	case outputAsK8sSecret:
+		if name == "" {
+			return errdef.Validation(fmt.Errorf("--name is required with --output-as %s", outputAsK8sSecret))
+		}
+		return nil
+	default:
+		return errdef.Validation(fmt.Errorf("invalid output type %q: should be one of %s or %s", outputAs, outputAsK8sSecret, outputAsDockerConfig))
+	}
+}
+
+// writeK8sSecret writes a Kubernetes image pull secret manifest holding the
+// docker config.json credentials of the server
+func writeK8sSecret(out io.Writer, name, namespace, server, username, token string) error {
+	config, err := json.Marshal(dockerConfigJSON{
+		Auths: map[string]dockerConfigAuth{
+			server: {
+				Username: username,
+				Password: token,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + token)),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(k8sSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   k8sMetadata{Name: name, Namespace: namespace},
+		Type:       "kubernetes.io/dockerconfigjson",
+		Data: map[string]string{
+			".dockerconfigjson": base64.StdEncoding.EncodeToString(config),
+		},
+	}); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
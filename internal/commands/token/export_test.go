@@ -0,0 +1,56 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+func TestWriteK8sSecret(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	err := writeK8sSecret(buf, "regcred", "ns", "https://index.docker.io/v1/", "user", "dckr_pat_secret")
+	assert.NilError(t, err)
+	golden.Assert(t, buf.String(), "k8s-secret.golden")
+}
+
+func TestValidateOutputAs(t *testing.T) {
+	assert.NilError(t, validateOutputAs("", "", ""))
+	assert.NilError(t, validateOutputAs(outputAsDockerConfig, "", ""))
+	assert.NilError(t, validateOutputAs(outputAsK8sSecret, "regcred", ""))
+	assert.NilError(t, validateOutputAs(outputAsK8sSecret, "regcred", "ci"))
+
+	err := validateOutputAs(outputAsK8sSecret, "", "")
+	assert.Error(t, err, "--name is required with --output-as k8s-secret")
+	assert.Assert(t, errdef.IsValidation(err))
+	for _, tc := range []struct{ outputAs, name, namespace string }{
+		{outputAsDockerConfig, "regcred", ""},
+		{outputAsDockerConfig, "", "ci"},
+		{"", "regcred", "ci"},
+	} {
+		err = validateOutputAs(tc.outputAs, tc.name, tc.namespace)
+		assert.Error(t, err, "--name and --namespace are only supported with --output-as k8s-secret")
+		assert.Equal(t, errdef.ExitCode(err), 2)
+	}
+	err = validateOutputAs("helm", "", "")
+	assert.Error(t, err, `invalid output type "helm": should be one of k8s-secret or docker-config`)
+}
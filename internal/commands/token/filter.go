@@ -0,0 +1,160 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	sortAsc  = "asc"
+	sortDesc = "desc"
+)
+
+var (
+	tokenFilterKeys = []string{"description", "active", "scope", "created", "last-used", "expires"}
+	tokenSortKeys   = []string{"description", "created", "last-used", "expires"}
+)
+
+// tokenFilter selects tokens client side, Hub not supporting any filter
+type tokenFilter struct {
+	predicates []func(hub.Token) bool
+}
+
+func parseTokenFilters(exprs []string, now time.Time) (*tokenFilter, error) {
+	filters, err := filter.ParseAll(exprs, tokenFilterKeys...)
+	if err != nil {
+		return nil, errdef.Validation(err)
+	}
+	tf := &tokenFilter{}
+	for _, f := range filters {
+		predicate, err := tokenPredicate(f, now)
+		if err != nil {
+			return nil, errdef.Validation(err)
+		}
+		tf.predicates = append(tf.predicates, predicate)
+	}
+	return tf, nil
+}
+
+func tokenPredicate(f filter.Filter, now time.Time) (func(hub.Token) bool, error) {
+	switch f.Key {
+	case "description":
+		match, err := f.StringMatcher()
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Token) bool { return match(t.Description) }, nil
+	case "scope":
+		match, err := f.StringMatcher()
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Token) bool { return match(getScopes(t.Scopes)) }, nil
+	case "active":
+		match, err := f.BoolMatcher()
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Token) bool { return match(t.IsActive) }, nil
+	case "created", "last-used", "expires":
+		match, err := f.TimeMatcher(now)
+		if err != nil {
+			return nil, err
+		}
+		return func(t hub.Token) bool { return match(tokenTime(t, f.Key)) }, nil
+	default:
+		return nil, fmt.Errorf("invalid filter %q: unknown key %q", f, f.Key)
+	}
+}
+
+// empty returns true if no token is filtered out
+func (f *tokenFilter) empty() bool {
+	return len(f.predicates) == 0
+}
+
+func (f *tokenFilter) apply(tokens []hub.Token) []hub.Token {
+	var result []hub.Token
+	for _, t := range tokens {
+		if f.match(t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (f *tokenFilter) match(t hub.Token) bool {
+	for _, predicate := range f.predicates {
+		if !predicate(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTokenOrdering parses a KEY[=(asc|desc)] sorting order, returning a nil
+// comparison if the order is empty
+func parseTokenOrdering(order string) (func(a, b hub.Token) bool, error) {
+	if order == "" {
+		return nil, nil
+	}
+	key, direction, _ := strings.Cut(order, "=")
+	descending := false
+	switch direction {
+	case "", sortAsc:
+	case sortDesc:
+		descending = true
+	default:
+		return nil, errdef.Validation(fmt.Errorf(`invalid sorting direction %q: should be either "asc" or "desc"`, direction))
+	}
+	var less func(a, b hub.Token) bool
+	switch key {
+	case "description":
+		less = func(a, b hub.Token) bool { return strings.ToLower(a.Description) < strings.ToLower(b.Description) }
+	case "created", "last-used", "expires":
+		// A zero time, like a token never used, is sorted first
+		less = func(a, b hub.Token) bool { return tokenTime(a, key).Before(tokenTime(b, key)) }
+	default:
+		return nil, errdef.Validation(fmt.Errorf("unknown sorting column %q: should be one of %s", key, strings.Join(tokenSortKeys, ", ")))
+	}
+	if descending {
+		return func(a, b hub.Token) bool { return less(b, a) }, nil
+	}
+	return less, nil
+}
+
+func sortTokens(tokens []hub.Token, less func(a, b hub.Token) bool) {
+	sort.SliceStable(tokens, func(i, j int) bool { return less(tokens[i], tokens[j]) })
+}
+
+func tokenTime(t hub.Token, key string) time.Time {
+	switch key {
+	case "created":
+		return t.CreatedAt
+	case "last-used":
+		return t.LastUsed
+	default:
+		return t.ExpiresAt
+	}
+}
@@ -0,0 +1,90 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+var (
+	filterNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tokens    = []hub.Token{
+		{Description: "ci-build", IsActive: true, CreatedAt: filterNow.Add(-400 * 24 * time.Hour), LastUsed: filterNow.Add(-time.Hour)},
+		{Description: "laptop", IsActive: false, CreatedAt: filterNow.Add(-200 * 24 * time.Hour), Scopes: []string{hub.ScopeRepoRead}},
+		{Description: "ci-deploy", IsActive: true, CreatedAt: filterNow.Add(-10 * 24 * time.Hour), LastUsed: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+)
+
+func descriptions(tokens []hub.Token) []string {
+	var result []string
+	for _, t := range tokens {
+		result = append(result, t.Description)
+	}
+	return result
+}
+
+func TestTokenFilters(t *testing.T) {
+	testCases := []struct {
+		filters  []string
+		expected []string
+	}{
+		{[]string{"description=ci-*"}, []string{"ci-build", "ci-deploy"}},
+		{[]string{"active=false"}, []string{"laptop"}},
+		{[]string{"last-used<2024-01-01"}, []string{"laptop", "ci-deploy"}},
+		{[]string{"description=ci-*", "last-used<2024-01-01"}, []string{"ci-deploy"}},
+		{[]string{"created<1y"}, []string{"ci-build"}},
+		{[]string{"scope=repo:read"}, []string{"laptop"}},
+	}
+	for _, tc := range testCases {
+		filters, err := parseTokenFilters(tc.filters, filterNow)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, descriptions(filters.apply(tokens)), tc.expected)
+	}
+
+	_, err := parseTokenFilters([]string{"label=ci"}, filterNow)
+	assert.ErrorContains(t, err, `unknown key "label"`)
+	_, err = parseTokenFilters([]string{"active<true"}, filterNow)
+	assert.ErrorContains(t, err, `operator "<" is not supported`)
+}
+
+func TestSortTokens(t *testing.T) {
+	sorted := append([]hub.Token{}, tokens...)
+	less, err := parseTokenOrdering("description")
+	assert.NilError(t, err)
+	sortTokens(sorted, less)
+	assert.DeepEqual(t, descriptions(sorted), []string{"ci-build", "ci-deploy", "laptop"})
+
+	less, err = parseTokenOrdering("last-used=desc")
+	assert.NilError(t, err)
+	sortTokens(sorted, less)
+	assert.DeepEqual(t, descriptions(sorted), []string{"ci-build", "ci-deploy", "laptop"})
+
+	less, err = parseTokenOrdering("created=asc")
+	assert.NilError(t, err)
+	sortTokens(sorted, less)
+	assert.DeepEqual(t, descriptions(sorted), []string{"ci-build", "laptop", "ci-deploy"})
+
+	_, err = parseTokenOrdering("uuid")
+	assert.Error(t, err, "unknown sorting column \"uuid\": should be one of description, created, last-used, expires")
+	_, err = parseTokenOrdering("created=up")
+	assert.Error(t, err, `invalid sorting direction "up": should be either "asc" or "desc"`)
+}
@@ -29,8 +29,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -48,6 +50,7 @@ func newInspectCmd(streams command.Streams, hubClient *hub.Client, parent string
 		Use:                   inspectName + " [OPTIONS] TOKEN_UUID",
 		Short:                 "Inspect a Personal Access Token",
 		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Tokens(hubClient)),
 		DisableFlagsInUseLine: true,
 		Annotations: map[string]string{
 			"sudo": "true",
@@ -72,7 +75,7 @@ func runInspect(streams command.Streams, hubClient *hub.Client, opts inspectOpti
 	if err != nil {
 		return err
 	}
-	return opts.Print(streams.Out(), token, printInspectToken)
+	return opts.PrintResource(streams.Out(), format.Resource{Kind: schema.KindToken, Value: token, Item: schema.NewToken(*token)}, printInspectToken)
 }
 
 func printInspectToken(out io.Writer, value interface{}) error {
@@ -84,6 +87,8 @@ func printInspectToken(out io.Writer, value interface{}) error {
 		fmt.Fprintf(out, ansi.Key("Description:")+"\t%s\n", token.Description)
 	}
 	fmt.Fprintf(out, ansi.Key("Is Active:")+"\t%v\n", token.IsActive)
+	fmt.Fprintf(out, ansi.Key("Scopes:")+"\t%s\n", getScopes(token.Scopes))
+	fmt.Fprintf(out, ansi.Key("Expires:")+"\t%s\n", getExpiresAt(token.ExpiresAt))
 	fmt.Fprintf(out, ansi.Key("Created:")+"\t%s\n", fmt.Sprintf("%s ago", units.HumanDuration(time.Since(token.CreatedAt))))
 	fmt.Fprintf(out, ansi.Key("Last Used:")+"\t%s\n", getLastUsed(token.LastUsed))
 	fmt.Fprintf(out, ansi.Key("Creator User Agent:")+"\t%s\n", token.CreatorUA)
@@ -99,6 +104,24 @@ func getLastUsed(t time.Time) string {
 	return fmt.Sprintf("%s ago", units.HumanDuration(time.Since(t)))
 }
 
+// getScopes returns the scopes of the token, the tokens created without scopes having all the permissions
+func getScopes(scopes []string) string {
+	if len(scopes) == 0 {
+		return hub.ScopeRepoAdmin
+	}
+	return strings.Join(scopes, ", ")
+}
+
+func getExpiresAt(t time.Time) string {
+	if t.IsZero() {
+		return "Never"
+	}
+	if t.Before(time.Now()) {
+		return fmt.Sprintf("Expired %s ago", units.HumanDuration(time.Since(t)))
+	}
+	return fmt.Sprintf("In %s", units.HumanDuration(time.Until(t)))
+}
+
 func getGeneratedBy(token *hub.Token) string {
 	if strings.Contains(token.CreatorUA, "hub-tool") {
 		return "By hub-tool"
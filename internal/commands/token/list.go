@@ -30,6 +30,7 @@ import (
 	"github.com/docker/hub-tool/internal/format"
 	"github.com/docker/hub-tool/internal/format/tabwriter"
 	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/internal/schema"
 	"github.com/docker/hub-tool/pkg/hub"
 )
 
@@ -41,6 +42,10 @@ var (
 	defaultColumns = []column{
 		{"DESCRIPTION", func(t hub.Token) (string, int) { return t.Description, len(t.Description) }},
 		{"UUID", func(t hub.Token) (string, int) { return t.UUID.String(), len(t.UUID.String()) }},
+		{"SCOPES", func(t hub.Token) (string, int) {
+			s := getScopes(t.Scopes)
+			return s, len(s)
+		}},
 		{"LAST USED", func(t hub.Token) (string, int) {
 			s := "Never"
 			if !t.LastUsed.IsZero() {
@@ -52,11 +57,21 @@ var (
 			s := units.HumanDuration(time.Since(t.CreatedAt))
 			return s, len(s)
 		}},
+		{"EXPIRES", func(t hub.Token) (string, int) {
+			s := getExpiresAt(t.ExpiresAt)
+			return s, len(s)
+		}},
 		{"ACTIVE", func(t hub.Token) (string, int) {
 			s := fmt.Sprintf("%v", t.IsActive)
 			return s, len(s)
 		}},
 	}
+	// optionalColumns are only printed when selected with --columns
+	optionalColumns = []column{
+		{"CREATOR IP", func(t hub.Token) (string, int) { return t.CreatorIP, len(t.CreatorIP) }},
+		{"CREATOR UA", func(t hub.Token) (string, int) { return t.CreatorUA, len(t.CreatorUA) }},
+		{"GENERATED BY", func(t hub.Token) (string, int) { return t.GeneratedBy, len(t.GeneratedBy) }},
+	}
 )
 
 type column struct {
@@ -64,9 +79,21 @@ type column struct {
 	value  func(t hub.Token) (string, int)
 }
 
+func columnHeader(c column) string {
+	return c.header
+}
+
+func columnValue(c column, t hub.Token) string {
+	value, _ := c.value(t)
+	return value
+}
+
 type listOptions struct {
 	format.Option
-	all bool
+	tabwriter.Options
+	all     bool
+	sort    string
+	filters []string
 }
 
 func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
@@ -88,34 +115,75 @@ func newListCmd(streams command.Streams, hubClient *hub.Client, parent string) *
 		},
 	}
 	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch all available tokens")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort tokens by (description|created|last-used|expires)[=(asc|desc)] (e.g.: --sort last-used)")
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", nil,
+		"Filter tokens by description, active, scope, created, last-used or expires (e.g.: --filter description=ci-* --filter active=false --filter last-used<2024-01-01)")
 	opts.AddFormatFlag(cmd.Flags())
+	opts.AddColumnsFlags(cmd.Flags(), tabwriter.Headers(columnHeader, defaultColumns, optionalColumns))
 	return cmd
 }
 
 func runList(streams command.Streams, hubClient *hub.Client, opts listOptions) error {
-	if opts.all {
+	columns, err := tabwriter.SelectColumns(&opts.Options, defaultColumns, optionalColumns, columnHeader)
+	if err != nil {
+		return err
+	}
+	filters, err := parseTokenFilters(opts.filters, time.Now())
+	if err != nil {
+		return err
+	}
+	less, err := parseTokenOrdering(opts.sort)
+	if err != nil {
+		return err
+	}
+	// Filtering or sorting only a page of tokens would be misleading
+	if opts.all || !filters.empty() || less != nil {
 		if err := hubClient.Update(hub.WithAllElements()); err != nil {
 			return err
 		}
 	}
+	if opts.IsStreaming() && less == nil {
+		// Print the tokens page by page, as soon as they are fetched
+		if err := hubClient.Update(hub.WithPageHandler(func(page interface{}) error {
+			tokens := filters.apply(page.([]hub.Token))
+			return opts.PrintList(streams.Out(), format.List{Kind: schema.KindToken, Values: tokens, Items: schema.NewTokens(tokens)}, nil, nil)
+		})); err != nil {
+			return err
+		}
+		_, _, err := hubClient.GetTokens()
+		return err
+	}
 	tokens, total, err := hubClient.GetTokens()
 	if err != nil {
 		return err
 	}
-	return opts.Print(streams.Out(), tokens, printTokens(total))
+	if !filters.empty() {
+		tokens = filters.apply(tokens)
+		total = len(tokens)
+	}
+	if less != nil {
+		sortTokens(tokens, less)
+	}
+	list := format.List{
+		Kind:   schema.KindToken,
+		Total:  total,
+		Values: tokens,
+		Items:  schema.NewTokens(tokens),
+	}
+	return opts.PrintList(streams.Out(), list, printTokens(total, columns, &opts.Options), tabwriter.Records(columns, columnHeader, columnValue))
 }
 
-func printTokens(total int) format.PrettyPrinter {
+func printTokens(total int, columns []column, table *tabwriter.Options) format.PrettyPrinter {
 	return func(out io.Writer, values interface{}) error {
 		tokens := values.([]hub.Token)
-		tw := tabwriter.New(out, "    ")
-		for _, column := range defaultColumns {
+		tw := table.New(out, "    ")
+		for _, column := range columns {
 			tw.Column(ansi.Header(column.header), len(column.header))
 		}
 
 		tw.Line()
 		for _, token := range tokens {
-			for _, column := range defaultColumns {
+			for _, column := range columns {
 				value, width := column.value(token)
 				tw.Column(value, width)
 			}
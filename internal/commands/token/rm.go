@@ -18,15 +18,16 @@ package token
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"strings"
 
-	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
 	"github.com/docker/hub-tool/internal/metrics"
 	"github.com/docker/hub-tool/pkg/hub"
 )
@@ -36,15 +37,15 @@ const (
 )
 
 type removeOptions struct {
-	force bool
+	bulkOptions
 }
 
 func newRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
 	var opts removeOptions
 	cmd := &cobra.Command{
-		Use:                   removeNAme + " [OPTIONS] TOKEN_UUID",
-		Short:                 "Delete a Personal Access Token",
-		Args:                  cli.ExactArgs(1),
+		Use:                   removeNAme + " [OPTIONS] [TOKEN_UUID...]",
+		Short:                 "Delete Personal Access Tokens",
+		ValidArgsFunction:     completion.Tokens(hubClient),
 		DisableFlagsInUseLine: true,
 		Annotations: map[string]string{
 			"sudo": "true",
@@ -52,14 +53,38 @@ func newRmCmd(streams command.Streams, hubClient *hub.Client, parent string) *co
 		PreRun: func(cmd *cobra.Command, args []string) {
 			metrics.Send(parent, removeNAme)
 		},
-		RunE: func(_ *cobra.Command, args []string) error {
-			return runRemove(streams, hubClient, opts, args[0])
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.single(args) {
+				return runRemove(streams, hubClient, opts, args[0])
+			}
+			return runBulkRemove(cmd.Context(), streams, hubClient, opts, args)
 		},
 	}
-	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Force deletion of the tag")
+	opts.addBulkFlags(cmd.Flags(), "Delete")
 	return cmd
 }
 
+func runBulkRemove(ctx context.Context, streams command.Streams, hubClient *hub.Client, opts removeOptions, args []string) error {
+	tokens, err := selectTokens(hubClient, args, opts.filters)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Fprintln(streams.Out(), ansi.Info("No token to delete"))
+		return nil
+	}
+	if !opts.force {
+		fmt.Fprintln(streams.Out(), ansi.Warn("WARNING: This action is irreversible.")+`
+Removing the tokens will invalidate your credentials on all Docker clients currently authenticated with the tokens.`)
+		if err := confirmBulk(ctx, streams, "delete", tokens); err != nil {
+			return err
+		}
+	}
+	return runBulk(streams.Out(), streams.Err(), "delete", tokens, func(t hub.Token) error {
+		return hubClient.RemoveToken(t.UUID.String())
+	}, "Access token deleted %s")
+}
+
 func runRemove(streams command.Streams, hubClient *hub.Client, opts removeOptions, tokenUUID string) error {
 	u, err := uuid.Parse(tokenUUID)
 	if err != nil {
@@ -0,0 +1,228 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/config"
+	clitypes "github.com/docker/cli/cli/config/types"
+	"github.com/docker/go-units"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/completion"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/filter"
+	"github.com/docker/hub-tool/internal/metrics"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	rotateName = "rotate"
+
+	// rollbackTimeout bounds the rollback, which isn't canceled with the command
+	rollbackTimeout = 30 * time.Second
+)
+
+type rotateOptions struct {
+	output       string
+	dockerConfig string
+	gracePeriod  string
+	remove       bool
+}
+
+// undoFunc reverts the write of the new token to a target
+type undoFunc func() error
+
+func newRotateCmd(streams command.Streams, hubClient *hub.Client, parent string) *cobra.Command {
+	var opts rotateOptions
+	cmd := &cobra.Command{
+		Use:   rotateName + " [OPTIONS] TOKEN_UUID",
+		Short: "Replace a Personal Access Token by a new one",
+		Long: `Replace a Personal Access Token by a new one with the same description and scopes.
+
+The new token is written to the standard output, to a file or to a docker config.json,
+then the old token is deactivated, or removed with --rm, once the grace period is over.
+If any step fails, the new token is removed and the targets are restored.`,
+		Args:                  cli.ExactArgs(1),
+		ValidArgsFunction:     completion.Limit(1, completion.Tokens(hubClient)),
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			"sudo": "true",
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			metrics.Send(parent, rotateName)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRotate(cmd.Context(), streams.Out(), streams.Err(), hubClient, opts, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", `Write the new token to a file ("-" for the standard output, the default without --docker-config)`)
+	cmd.Flags().StringVar(&opts.dockerConfig, "docker-config", "", "Store the new token as the registry credentials of the docker config.json in this directory")
+	cmd.Flags().Lookup("docker-config").NoOptDefVal = config.Dir()
+	cmd.Flags().StringVar(&opts.gracePeriod, "grace-period", "", "Wait before retiring the old token, like 10m or 1d")
+	cmd.Flags().BoolVar(&opts.remove, "rm", false, "Remove the old token instead of deactivating it")
+	return cmd
+}
+
+func runRotate(ctx context.Context, out, log io.Writer, hubClient *hub.Client, opts rotateOptions, tokenUUID string) error {
+	u, err := uuid.Parse(tokenUUID)
+	if err != nil {
+		return errdef.Validation(err)
+	}
+	var gracePeriod time.Duration
+	if opts.gracePeriod != "" {
+		if gracePeriod, err = filter.ParseDuration(opts.gracePeriod); err != nil {
+			return errdef.Validation(err)
+		}
+	}
+	if opts.output == "" && opts.dockerConfig == "" {
+		opts.output = "-"
+	}
+
+	old, err := hubClient.GetToken(u.String())
+	if err != nil {
+		return err
+	}
+	// Keep the lifetime of the old token
+	var expiresAt time.Time
+	if !old.ExpiresAt.IsZero() {
+		expiresAt = time.Now().Add(old.ExpiresAt.Sub(old.CreatedAt))
+	}
+	token, err := hubClient.CreateTokenWithOptions(old.Description, old.Scopes, expiresAt)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(log, ansi.Info("Created token %s\n"), token.UUID)
+
+	var undos []undoFunc
+	rollback := func(cause error) error {
+		// The context is canceled on Ctrl-C, the rollback must still reach Hub
+		rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+		defer cancel()
+		if err := hubClient.Update(hub.WithContext(rollbackCtx)); err != nil {
+			return fmt.Errorf("%w (rollback failed: %s, the new token %s is still active)", cause, err, token.UUID)
+		}
+		for i := len(undos) - 1; i >= 0; i-- {
+			if err := undos[i](); err != nil {
+				return fmt.Errorf("%w (rollback failed: %s, the new token %s is still active)", cause, err, token.UUID)
+			}
+		}
+		if err := hubClient.RemoveToken(token.UUID.String()); err != nil {
+			return fmt.Errorf("%w (rollback failed: %s, the new token %s is still active)", cause, err, token.UUID)
+		}
+		return fmt.Errorf("%w (rolled back: the new token %s was removed, %s is still active)", cause, token.UUID, old.UUID)
+	}
+
+	if opts.output != "" {
+		undo, err := writeTokenFile(out, opts.output, token)
+		if err != nil {
+			return rollback(err)
+		}
+		undos = append(undos, undo)
+	}
+	if opts.dockerConfig != "" {
+		undo, err := storeDockerConfig(opts.dockerConfig, hubClient.AuthConfig.Username, token)
+		if err != nil {
+			return rollback(err)
+		}
+		undos = append(undos, undo)
+		fmt.Fprintf(log, ansi.Info("Stored token %s in %s\n"), token.UUID, opts.dockerConfig)
+	}
+
+	if gracePeriod > 0 {
+		fmt.Fprintf(log, ansi.Info("Waiting %s before retiring token %s\n"), units.HumanDuration(gracePeriod), old.UUID)
+		select {
+		case <-ctx.Done():
+			return rollback(ctx.Err())
+		case <-time.After(gracePeriod):
+		}
+	}
+
+	if opts.remove {
+		if err := hubClient.RemoveToken(old.UUID.String()); err != nil {
+			return rollback(err)
+		}
+		fmt.Fprintf(log, ansi.Emphasise("Removed token %s\n"), old.UUID)
+		return nil
+	}
+	if _, err := hubClient.UpdateToken(old.UUID.String(), "", false); err != nil {
+		return rollback(err)
+	}
+	fmt.Fprintf(log, ansi.Emphasise("Deactivated token %s\n"), old.UUID)
+	return nil
+}
+
+// writeTokenFile writes the token to the file, or to the output if the file is "-".
+// Undoing restores the previous content of the file, nothing can be done for the output.
+func writeTokenFile(out io.Writer, file string, token *hub.Token) (undoFunc, error) {
+	if file == "-" {
+		_, err := fmt.Fprintln(out, token.Token)
+		return func() error { return nil }, err
+	}
+	previous, err := os.ReadFile(file)
+	existed := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.WriteFile(file, []byte(token.Token+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return func() error {
+		if existed {
+			return os.WriteFile(file, previous, 0o600)
+		}
+		return os.Remove(file)
+	}, nil
+}
+
+// storeDockerConfig stores the token as the registry credentials of the docker config,
+// in its credentials store if it has one. Undoing restores the previous credentials.
+func storeDockerConfig(dir, username string, token *hub.Token) (undoFunc, error) {
+	configFile, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	server := hub.RegistryServer()
+	store := configFile.GetCredentialsStore(server)
+	previous, err := store.Get(server)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Store(clitypes.AuthConfig{
+		Username:      username,
+		Password:      token.Token,
+		ServerAddress: server,
+	}); err != nil {
+		return nil, err
+	}
+	return func() error {
+		if previous == (clitypes.AuthConfig{}) {
+			return store.Erase(server)
+		}
+		return store.Store(previous)
+	}, nil
+}
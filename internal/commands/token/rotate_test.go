@@ -0,0 +1,158 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package token
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/docker/registry"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+const (
+	oldUUID = "2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a"
+	newUUID = "6f1d3c1e-9a0b-4c55-a3f1-0c8e2b7d9e44"
+)
+
+// newRotationServer fakes the Hub tokens API, failing the update of the old token if asked to
+func newRotationServer(t *testing.T, failUpdate bool) (*hub.Client, *[]string) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/api_tokens/"+oldUUID:
+			fmt.Fprintf(w, `{"uuid": %q, "token_label": "ci", "is_active": true, "scopes": ["repo:read"]}`, oldUUID)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/api_tokens":
+			body, err := io.ReadAll(r.Body)
+			assert.NilError(t, err)
+			assert.Equal(t, string(body), `{"token_label":"ci","is_active":false,"scopes":["repo:read"]}`)
+			fmt.Fprintf(w, `{"uuid": %q, "token_label": "ci", "is_active": true, "scopes": ["repo:read"], "token": "dckr_pat_new"}`, newUUID)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/api_tokens/"+oldUUID:
+			if failUpdate {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(w, `{"uuid": %q, "token_label": "ci", "is_active": false}`, oldUUID)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/api_tokens/"+newUUID:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("DOCKER_HUB_API_URL", server.URL)
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+	hubClient, err := hub.NewClient(hub.WithHubAccount("user"))
+	assert.NilError(t, err)
+	return hubClient, &calls
+}
+
+func TestRotateWritesTheNewTokenAndDeactivatesTheOldOne(t *testing.T) {
+	hubClient, calls := newRotationServer(t, false)
+	file := filepath.Join(t.TempDir(), "token")
+	log := bytes.NewBuffer(nil)
+
+	err := runRotate(context.Background(), io.Discard, log, hubClient, rotateOptions{output: file}, oldUUID)
+	assert.NilError(t, err)
+	content, err := os.ReadFile(file)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "dckr_pat_new\n")
+	assert.DeepEqual(t, *calls, []string{
+		"GET /v2/api_tokens/" + oldUUID,
+		"POST /v2/api_tokens",
+		"PATCH /v2/api_tokens/" + oldUUID,
+	})
+	assert.Equal(t, log.String(), "Created token "+newUUID+"\nDeactivated token "+oldUUID+"\n")
+}
+
+func TestRotateRollsBackWhenRetiringFails(t *testing.T) {
+	hubClient, calls := newRotationServer(t, true)
+	file := filepath.Join(t.TempDir(), "token")
+	assert.NilError(t, os.WriteFile(file, []byte("dckr_pat_old\n"), 0o600))
+
+	err := runRotate(context.Background(), io.Discard, io.Discard, hubClient, rotateOptions{output: file}, oldUUID)
+	assert.ErrorContains(t, err, "rolled back: the new token "+newUUID+" was removed, "+oldUUID+" is still active")
+	content, err := os.ReadFile(file)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "dckr_pat_old\n")
+	assert.DeepEqual(t, *calls, []string{
+		"GET /v2/api_tokens/" + oldUUID,
+		"POST /v2/api_tokens",
+		"PATCH /v2/api_tokens/" + oldUUID,
+		"DELETE /v2/api_tokens/" + newUUID,
+	})
+}
+
+func TestRotateRollsBackWhenCanceledDuringTheGracePeriod(t *testing.T) {
+	hubClient, calls := newRotationServer(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	// Like main, the client shares the context canceled on Ctrl-C
+	assert.NilError(t, hubClient.Update(hub.WithContext(ctx)))
+	log := &cancelingWriter{cancel: cancel, trigger: "Waiting"}
+
+	err := runRotate(ctx, io.Discard, log, hubClient, rotateOptions{output: "-", gracePeriod: "1h"}, oldUUID)
+	assert.ErrorContains(t, err, "context canceled (rolled back: the new token "+newUUID+" was removed, "+oldUUID+" is still active)")
+	assert.DeepEqual(t, *calls, []string{
+		"GET /v2/api_tokens/" + oldUUID,
+		"POST /v2/api_tokens",
+		"DELETE /v2/api_tokens/" + newUUID,
+	})
+}
+
+// cancelingWriter cancels the context once the trigger is written
+type cancelingWriter struct {
+	bytes.Buffer
+	cancel  context.CancelFunc
+	trigger string
+}
+
+func (w *cancelingWriter) Write(p []byte) (int, error) {
+	if bytes.Contains(p, []byte(w.trigger)) {
+		w.cancel()
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestStoreDockerConfig(t *testing.T) {
+	dir := t.TempDir()
+	undo, err := storeDockerConfig(dir, "user", &hub.Token{Token: "dckr_pat_new"})
+	assert.NilError(t, err)
+
+	configFile, err := config.Load(dir)
+	assert.NilError(t, err)
+	auth, err := configFile.GetAuthConfig(registry.IndexServer)
+	assert.NilError(t, err)
+	assert.Equal(t, auth.Username, "user")
+	assert.Equal(t, auth.Password, "dckr_pat_new")
+
+	assert.NilError(t, undo())
+	configFile, err = config.Load(dir)
+	assert.NilError(t, err)
+	_, ok := configFile.AuthConfigs[registry.IndexServer]
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,87 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheTTL is how long the completion candidates are reused before asking Hub again
+const cacheTTL = 2 * time.Minute
+
+type cacheEntry struct {
+	Created    time.Time `json:"created"`
+	Candidates []string  `json:"candidates"`
+}
+
+// cache stores the completion candidates on disk, so that completing the same
+// argument several times in a row doesn't call Hub each time
+type cache struct {
+	dir string
+	now func() time.Time
+}
+
+func newCache() *cache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return &cache{now: time.Now}
+	}
+	return &cache{dir: filepath.Join(dir, "hub-tool", "completion"), now: time.Now}
+}
+
+// cacheKey joins the parts of a key with a separator which can't be part of a
+// name, so that "user/repo" with the prefix "a-b" and "user/repo-a" with "b" differ
+func cacheKey(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// path hashes the key into a file name, whatever the characters the user typed
+func (c *cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// get returns the cached candidates, calling fetch and storing its result if
+// there is no fresh entry. Failing to read or write the cache is not an error,
+// completion just gets slower.
+func (c *cache) get(key string, fetch func() ([]string, error)) ([]string, error) {
+	if c.dir != "" {
+		if data, err := os.ReadFile(c.path(key)); err == nil {
+			var entry cacheEntry
+			if err := json.Unmarshal(data, &entry); err == nil && c.now().Sub(entry.Created) < cacheTTL {
+				return entry.Candidates, nil
+			}
+		}
+	}
+	candidates, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if c.dir != "" {
+		data, err := json.Marshal(cacheEntry{Created: c.now(), Candidates: candidates})
+		if err == nil && os.MkdirAll(c.dir, 0o700) == nil {
+			_ = os.WriteFile(c.path(key), data, 0o600)
+		}
+	}
+	return candidates, nil
+}
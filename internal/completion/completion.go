@@ -0,0 +1,315 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package completion provides the dynamic shell completion of the command arguments,
+// listing the namespaces, repositories, tags, tokens, members, teams and invites from Hub.
+package completion
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// Func completes the positional arguments of a command
+type Func func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// Limit stops completing once the command has max positional arguments
+func Limit(max int, complete Func) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= max {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// Organizations completes the organizations the user has joined
+func Organizations(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		organizations, err := organizations(cmd, hubClient)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(organizations, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Namespaces completes the user account and the organizations the user has joined
+func Namespaces(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		namespaces, err := namespaces(cmd, hubClient)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(namespaces, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Repositories completes NAMESPACE/REPOSITORY, first the namespace then the repositories in it
+func Repositories(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return repositories(cmd, hubClient, toComplete, "")
+	}
+}
+
+// Tags completes REPOSITORY:TAG, first the repository then the tags whose name starts with the typed prefix
+func Tags(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		repository, prefix, ok := strings.Cut(toComplete, ":")
+		if !ok {
+			return repositories(cmd, hubClient, toComplete, ":")
+		}
+		key := cacheKey("tags", hubClient.AuthConfig.Username, repository, prefix)
+		tags, err := newCache().get(key, func() ([]string, error) {
+			var reqOps []hub.RequestOp
+			if prefix != "" {
+				reqOps = append(reqOps, hub.WithNameFilter(prefix))
+			}
+			tags, _, err := hubClient.GetTags(repository, reqOps...)
+			if err != nil {
+				return nil, err
+			}
+			var names []string
+			for _, tag := range tags {
+				names = append(names, tag.Name)
+			}
+			return names, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(tags, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Tokens completes the personal access token UUIDs, described by their label
+func Tokens(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		key := cacheKey("tokens", hubClient.AuthConfig.Username)
+		tokens, err := newCache().get(key, func() ([]string, error) {
+			tokens, _, err := hubClient.GetTokens()
+			if err != nil {
+				return nil, err
+			}
+			var candidates []string
+			for _, token := range tokens {
+				candidates = append(candidates, describe(token.UUID.String(), token.Description))
+			}
+			return candidates, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(tokens, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// OrgTokens completes ORGANIZATION then the access token IDs of the organization, described by their label
+func OrgTokens(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return Organizations(hubClient)(cmd, args, toComplete)
+		}
+		key := cacheKey("org-tokens", hubClient.AuthConfig.Username, args[0])
+		tokens, err := newCache().get(key, func() ([]string, error) {
+			tokens, _, err := hubClient.GetOrgTokens(args[0])
+			if err != nil {
+				return nil, err
+			}
+			var candidates []string
+			for _, token := range tokens {
+				candidates = append(candidates, describe(token.ID.String(), token.Label))
+			}
+			return candidates, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(tokens, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Members completes ORGANIZATION then the usernames of its members, described by their full name
+func Members(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return Organizations(hubClient)(cmd, args, toComplete)
+		}
+		key := cacheKey("members", hubClient.AuthConfig.Username, args[0])
+		members, err := newCache().get(key, func() ([]string, error) {
+			members, err := hubClient.GetMembers(args[0])
+			if err != nil {
+				return nil, err
+			}
+			var candidates []string
+			for _, member := range members {
+				candidates = append(candidates, describe(member.Username, member.FullName))
+			}
+			return candidates, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(members, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Teams completes ORGANIZATION then the names of its teams, described by their description
+func Teams(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return Organizations(hubClient)(cmd, args, toComplete)
+		}
+		key := cacheKey("teams", hubClient.AuthConfig.Username, args[0])
+		teams, err := newCache().get(key, func() ([]string, error) {
+			teams, err := hubClient.GetTeams(args[0])
+			if err != nil {
+				return nil, err
+			}
+			var candidates []string
+			for _, team := range teams {
+				candidates = append(candidates, describe(team.Name, team.Description))
+			}
+			return candidates, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(teams, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// TeamMembers completes ORGANIZATION, TEAM then the usernames of the members of the organization
+func TeamMembers(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) < 2 {
+			return Teams(hubClient)(cmd, args, toComplete)
+		}
+		return Members(hubClient)(cmd, args[:1], toComplete)
+	}
+}
+
+// Invites completes ORGANIZATION then the invitees of its pending invites, described by their team
+func Invites(hubClient *hub.Client) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return Organizations(hubClient)(cmd, args, toComplete)
+		}
+		key := cacheKey("invites", hubClient.AuthConfig.Username, args[0])
+		invites, err := newCache().get(key, func() ([]string, error) {
+			invites, err := hubClient.GetInvites(args[0])
+			if err != nil {
+				return nil, err
+			}
+			var candidates []string
+			for _, invite := range invites {
+				candidates = append(candidates, describe(invite.Invitee, invite.Team))
+			}
+			return candidates, nil
+		})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filter(invites, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func repositories(cmd *cobra.Command, hubClient *hub.Client, toComplete, suffix string) ([]string, cobra.ShellCompDirective) {
+	namespace, _, ok := strings.Cut(toComplete, "/")
+	if !ok {
+		namespaces, err := namespaces(cmd, hubClient)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var candidates []string
+		for _, namespace := range filter(namespaces, toComplete) {
+			candidates = append(candidates, namespace+"/")
+		}
+		return candidates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+	key := cacheKey("repositories", hubClient.AuthConfig.Username, namespace)
+	repositories, err := newCache().get(key, func() ([]string, error) {
+		repositories, _, err := hubClient.GetRepositories(namespace)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, repository := range repositories {
+			names = append(names, repository.Name)
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	directive := cobra.ShellCompDirectiveNoFileComp
+	if suffix != "" {
+		directive |= cobra.ShellCompDirectiveNoSpace
+	}
+	var candidates []string
+	for _, repository := range filter(repositories, toComplete) {
+		candidates = append(candidates, repository+suffix)
+	}
+	return candidates, directive
+}
+
+func organizations(cmd *cobra.Command, hubClient *hub.Client) ([]string, error) {
+	key := cacheKey("organizations", hubClient.AuthConfig.Username)
+	return newCache().get(key, func() ([]string, error) {
+		organizations, err := hubClient.GetOrganizations(cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, organization := range organizations {
+			names = append(names, organization.Namespace)
+		}
+		return names, nil
+	})
+}
+
+func namespaces(cmd *cobra.Command, hubClient *hub.Client) ([]string, error) {
+	organizations, err := organizations(cmd, hubClient)
+	if err != nil {
+		return nil, err
+	}
+	if hubClient.AuthConfig.Username == "" {
+		return organizations, nil
+	}
+	return append([]string{hubClient.AuthConfig.Username}, organizations...), nil
+}
+
+// describe adds a description to the candidate, shown by the shells supporting it
+func describe(candidate, description string) string {
+	if description == "" {
+		return candidate
+	}
+	return candidate + "\t" + description
+}
+
+// filter keeps the candidates starting with the typed prefix
+func filter(candidates []string, toComplete string) []string {
+	var filtered []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,92 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package completion
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := &cache{dir: t.TempDir(), now: func() time.Time { return now }}
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"user/repo", "user/other"}, nil
+	}
+
+	candidates, err := c.get(cacheKey("repositories", "user", "user"), fetch)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, candidates, []string{"user/repo", "user/other"})
+	assert.Equal(t, calls, 1)
+
+	_, err = c.get(cacheKey("repositories", "user", "user"), fetch)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 1)
+
+	now = now.Add(cacheTTL)
+	_, err = c.get(cacheKey("repositories", "user", "user"), fetch)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+
+	_, err = c.get(cacheKey("repositories", "user", "other"), func() ([]string, error) { return nil, errors.New("boom") })
+	assert.Error(t, err, "boom")
+}
+
+func TestCacheKeyIsAFileName(t *testing.T) {
+	c := &cache{dir: "/cache"}
+	path := c.path(cacheKey("tags", "user", "user/repo", "v1.*"))
+	assert.Equal(t, filepath.Dir(path), "/cache")
+	assert.Assert(t, regexp.MustCompile(`^[0-9a-f]{64}\.json$`).MatchString(filepath.Base(path)), path)
+}
+
+func TestCacheKeysAreUnambiguous(t *testing.T) {
+	c := &cache{dir: "/cache"}
+	assert.Assert(t, c.path(cacheKey("tags", "user", "user/repo", "a-b")) != c.path(cacheKey("tags", "user", "user/repo-a", "b")))
+	assert.Assert(t, c.path(cacheKey("tags", "user", "user/repo", "v1.*")) != c.path(cacheKey("tags", "user", "user/repo", "v1._")))
+	assert.Assert(t, c.path(cacheKey("members", "user", "org")) != c.path(cacheKey("members", "user-org")))
+}
+
+func TestFilter(t *testing.T) {
+	candidates := []string{"user/repo:latest", "user/repo:1.0", "user/other:latest"}
+	assert.DeepEqual(t, filter(candidates, "user/repo:"), []string{"user/repo:latest", "user/repo:1.0"})
+	assert.DeepEqual(t, filter(candidates, ""), candidates)
+	assert.Assert(t, filter(candidates, "org/") == nil)
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, describe("2ba7ea3c", "ci token"), "2ba7ea3c\tci token")
+	assert.Equal(t, describe("2ba7ea3c", ""), "2ba7ea3c")
+}
+
+func TestLimit(t *testing.T) {
+	complete := Limit(1, func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{"user"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	candidates, _ := complete(nil, nil, "")
+	assert.DeepEqual(t, candidates, []string{"user"})
+	candidates, directive := complete(nil, []string{"user"}, "")
+	assert.Assert(t, candidates == nil)
+	assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+}
@@ -20,3 +20,6 @@ import "errors"
 
 // ErrCanceled represents a normally canceled operation
 var ErrCanceled = errors.New("canceled")
+
+// ErrNotFound is returned when a resource given by the user doesn't exist
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,135 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package errdef
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/containerd/containerd/errdefs"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// Class is the kind of failure an error represents, used to pick the exit code
+type Class string
+
+const (
+	// ClassGeneral is any error not falling in another class
+	ClassGeneral Class = "general"
+	// ClassValidation is an invalid argument, flag or value given by the user
+	ClassValidation Class = "validation"
+	// ClassAuthRequired is returned when the user is not logged in or the credentials were rejected
+	ClassAuthRequired Class = "auth_required"
+	// ClassForbidden is returned when the user is not allowed to perform the operation
+	ClassForbidden Class = "forbidden"
+	// ClassNotFound is returned when the requested resource doesn't exist
+	ClassNotFound Class = "not_found"
+	// ClassRateLimited is returned when Hub refused the request because of too many requests
+	ClassRateLimited Class = "rate_limited"
+	// ClassCanceled is returned when the operation was interrupted
+	ClassCanceled Class = "canceled"
+)
+
+var exitCodes = map[Class]int{
+	ClassGeneral:      1,
+	ClassValidation:   2,
+	ClassAuthRequired: 3,
+	ClassForbidden:    4,
+	ClassNotFound:     5,
+	ClassRateLimited:  6,
+	ClassCanceled:     130,
+}
+
+// ErrAuthRequired is returned when a command needs the user to be logged in
+var ErrAuthRequired = errors.New(`You need to be logged in to Docker Hub to use this tool.
+Please login to Docker Hub using the "hub-tool login" command.`)
+
+type validationError struct {
+	err error
+}
+
+func (v *validationError) Error() string {
+	return v.err.Error()
+}
+
+func (v *validationError) Unwrap() error {
+	return v.err
+}
+
+// Validation marks the error as a user input error
+func Validation(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &validationError{err: err}
+}
+
+// IsValidation checks if the error is a user input error
+func IsValidation(err error) bool {
+	var v *validationError
+	return errors.As(err, &v)
+}
+
+// Classify returns the class of the error
+func Classify(err error) Class {
+	switch {
+	case errors.Is(err, ErrCanceled), errors.Is(err, context.Canceled):
+		return ClassCanceled
+	case IsValidation(err):
+		return ClassValidation
+	case errors.Is(err, ErrAuthRequired), hub.IsAuthenticationError(err), hub.IsInvalidTokenError(err):
+		return ClassAuthRequired
+	case hub.IsForbiddenError(err):
+		return ClassForbidden
+	case errors.Is(err, ErrNotFound), hub.IsNotFoundError(err), errdefs.IsNotFound(err):
+		return ClassNotFound
+	case hub.IsTooManyRequestsError(err):
+		return ClassRateLimited
+	default:
+		return ClassGeneral
+	}
+}
+
+// ExitCode returns the process exit code matching the class of the error
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return exitCodes[Classify(err)]
+}
+
+// Print writes the error to the output, as plain text or as a JSON object if the format is "json"
+func Print(out io.Writer, err error, format string) error {
+	if format != "json" {
+		_, e := fmt.Fprintln(out, "Error:", err)
+		return e
+	}
+	class := Classify(err)
+	return json.NewEncoder(out).Encode(struct {
+		Class    Class  `json:"class"`
+		Message  string `json:"message"`
+		ExitCode int    `json:"exit_code"`
+	}{
+		Class:    class,
+		Message:  err.Error(),
+		ExitCode: exitCodes[class],
+	})
+}
@@ -0,0 +1,56 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package errdef
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected int
+	}{
+		{nil, 0},
+		{errors.New("boom"), 1},
+		{Validation(errors.New("bad flag")), 2},
+		{fmt.Errorf("prefix: %w", Validation(errors.New("bad flag"))), 2},
+		{ErrAuthRequired, 3},
+		{ErrCanceled, 130},
+		{fmt.Errorf("token abc: %w", ErrNotFound), 5},
+		{fmt.Errorf("listing: %w", context.Canceled), 130},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, ExitCode(tc.err), tc.expected, "%v", tc.err)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	assert.NilError(t, Print(out, Validation(errors.New(`unknown column "foo"`)), "text"))
+	assert.Equal(t, out.String(), "Error: unknown column \"foo\"\n")
+
+	out.Reset()
+	assert.NilError(t, Print(out, Validation(errors.New(`unknown column "foo"`)), "json"))
+	assert.Equal(t, out.String(), `{"class":"validation","message":"unknown column \"foo\"","exit_code":2}`+"\n")
+}
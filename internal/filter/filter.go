@@ -0,0 +1,244 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+)
+
+// Operator compares a field with the value of a filter
+type Operator string
+
+const (
+	// Equal matches a glob pattern for strings, or an exact value otherwise
+	Equal = Operator("=")
+	// NotEqual is the negation of Equal
+	NotEqual = Operator("!=")
+	// Less matches values strictly lower, or times strictly before
+	Less = Operator("<")
+	// LessOrEqual matches values lower or equal
+	LessOrEqual = Operator("<=")
+	// Greater matches values strictly greater, or times strictly after
+	Greater = Operator(">")
+	// GreaterOrEqual matches values greater or equal
+	GreaterOrEqual = Operator(">=")
+	// Match matches a regular expression
+	Match = Operator("~")
+)
+
+// operators are ordered so that two characters operators are tried first
+var operators = []Operator{NotEqual, LessOrEqual, GreaterOrEqual, Equal, Less, Greater, Match}
+
+// Filter is a single KEY OPERATOR VALUE expression, like "name=v1.*" or "size>100MB"
+type Filter struct {
+	Key      string
+	Operator Operator
+	Value    string
+}
+
+func (f Filter) String() string {
+	return f.Key + string(f.Operator) + f.Value
+}
+
+// Parse parses a filter expression
+func Parse(expr string) (Filter, error) {
+	i := strings.IndexAny(expr, "!=<>~")
+	if i <= 0 {
+		return Filter{}, fmt.Errorf("invalid filter %q: expected KEY OPERATOR VALUE (e.g. name=v1.*)", expr)
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(expr[i:], string(op)) {
+			return Filter{
+				Key:      strings.ToLower(strings.TrimSpace(expr[:i])),
+				Operator: op,
+				Value:    strings.TrimSpace(expr[i+len(op):]),
+			}, nil
+		}
+	}
+	return Filter{}, fmt.Errorf("invalid filter %q: unknown operator", expr)
+}
+
+// ParseAll parses all the filter expressions, checking their keys are part of the supported ones
+func ParseAll(exprs []string, keys ...string) ([]Filter, error) {
+	var filters []Filter
+	for _, expr := range exprs {
+		f, err := Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(keys, f.Key) {
+			return nil, fmt.Errorf("invalid filter %q: unknown key %q, should be one of %s", expr, f.Key, strings.Join(keys, ", "))
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// StringMatcher returns a predicate matching a string against a glob pattern (=, !=) or a regular expression (~)
+func (f Filter) StringMatcher() (func(string) bool, error) {
+	switch f.Operator {
+	case Equal, NotEqual:
+		if _, err := path.Match(f.Value, ""); err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %s", f, err)
+		}
+		negate := f.Operator == NotEqual
+		return func(s string) bool {
+			ok, _ := path.Match(f.Value, s)
+			return ok != negate
+		}, nil
+	case Match:
+		re, err := regexp.Compile(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %s", f, err)
+		}
+		return re.MatchString, nil
+	default:
+		return nil, f.unsupportedOperator()
+	}
+}
+
+// BoolMatcher returns a predicate matching a boolean value
+func (f Filter) BoolMatcher() (func(bool) bool, error) {
+	value, err := strconv.ParseBool(f.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %q is not a boolean", f, f.Value)
+	}
+	switch f.Operator {
+	case Equal:
+		return func(b bool) bool { return b == value }, nil
+	case NotEqual:
+		return func(b bool) bool { return b != value }, nil
+	default:
+		return nil, f.unsupportedOperator()
+	}
+}
+
+// SizeMatcher returns a predicate comparing a size in bytes with a human readable size (e.g. 100MB)
+func (f Filter) SizeMatcher() (func(int64) bool, error) {
+	value, err := units.FromHumanSize(f.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %s", f, err)
+	}
+	return f.intMatcher(value)
+}
+
+func (f Filter) intMatcher(value int64) (func(int64) bool, error) {
+	switch f.Operator {
+	case Equal:
+		return func(i int64) bool { return i == value }, nil
+	case NotEqual:
+		return func(i int64) bool { return i != value }, nil
+	case Less:
+		return func(i int64) bool { return i < value }, nil
+	case LessOrEqual:
+		return func(i int64) bool { return i <= value }, nil
+	case Greater:
+		return func(i int64) bool { return i > value }, nil
+	case GreaterOrEqual:
+		return func(i int64) bool { return i >= value }, nil
+	default:
+		return nil, f.unsupportedOperator()
+	}
+}
+
+// TimeMatcher returns a predicate comparing a time with a date (2006-01-02 or RFC 3339)
+// or a duration relative to now (e.g. 30d). A zero time means "never" and is considered
+// older than any other time.
+func (f Filter) TimeMatcher(now time.Time) (func(time.Time) bool, error) {
+	value, err := ParseTime(f.Value, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %s", f, err)
+	}
+	switch f.Operator {
+	case Less:
+		return func(t time.Time) bool { return t.IsZero() || t.Before(value) }, nil
+	case LessOrEqual:
+		return func(t time.Time) bool { return t.IsZero() || !t.After(value) }, nil
+	case Greater:
+		return func(t time.Time) bool { return !t.IsZero() && t.After(value) }, nil
+	case GreaterOrEqual:
+		return func(t time.Time) bool { return !t.IsZero() && !t.Before(value) }, nil
+	default:
+		return nil, f.unsupportedOperator()
+	}
+}
+
+func (f Filter) unsupportedOperator() error {
+	return fmt.Errorf("invalid filter %q: operator %q is not supported for %q", f, f.Operator, f.Key)
+}
+
+// ParseTime parses a date (2006-01-02 or RFC 3339) or a duration, which is then subtracted from now
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	d, err := ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a date nor a duration", s)
+	}
+	return now.Add(-d), nil
+}
+
+// ParseDuration parses a duration, extending time.ParseDuration with days (d), weeks (w) and years (y)
+func ParseDuration(s string) (time.Duration, error) {
+	multipliers := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+	if len(s) > 1 {
+		if unit, ok := multipliers[s[len(s)-1]]; ok {
+			n, err := strconv.Atoi(s[:len(s)-1])
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// ParseExpiration converts a positive duration to the date it expires from now, an empty
+// duration giving the zero time which never expires
+func ParseExpiration(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	d, err := ParseDuration(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid expiration %q: should be a positive duration", s)
+	}
+	return now.Add(d), nil
+}
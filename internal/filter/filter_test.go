@@ -0,0 +1,156 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		expr          string
+		expected      Filter
+		expectedError string
+	}{
+		{expr: "name=v1.*", expected: Filter{Key: "name", Operator: Equal, Value: "v1.*"}},
+		{expr: "name!=*-rc", expected: Filter{Key: "name", Operator: NotEqual, Value: "*-rc"}},
+		{expr: "size>=100MB", expected: Filter{Key: "size", Operator: GreaterOrEqual, Value: "100MB"}},
+		{expr: "last-used<2024-01-01", expected: Filter{Key: "last-used", Operator: Less, Value: "2024-01-01"}},
+		{expr: "name~^v[0-9]+$", expected: Filter{Key: "name", Operator: Match, Value: "^v[0-9]+$"}},
+		{expr: "Platform=linux/arm64", expected: Filter{Key: "platform", Operator: Equal, Value: "linux/arm64"}},
+		{expr: "name", expectedError: `invalid filter "name": expected KEY OPERATOR VALUE (e.g. name=v1.*)`},
+		{expr: "=value", expectedError: `invalid filter "=value": expected KEY OPERATOR VALUE (e.g. name=v1.*)`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expr, func(t *testing.T) {
+			f, err := Parse(testCase.expr)
+			if testCase.expectedError != "" {
+				assert.Error(t, err, testCase.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, f, testCase.expected)
+		})
+	}
+}
+
+func TestParseAllUnknownKey(t *testing.T) {
+	_, err := ParseAll([]string{"name=foo", "color=blue"}, "name", "size")
+	assert.Error(t, err, `invalid filter "color=blue": unknown key "color", should be one of name, size`)
+}
+
+func TestStringMatcher(t *testing.T) {
+	glob, err := Filter{Key: "name", Operator: Equal, Value: "ci-*"}.StringMatcher()
+	assert.NilError(t, err)
+	assert.Assert(t, glob("ci-runner"))
+	assert.Assert(t, !glob("laptop"))
+
+	notGlob, err := Filter{Key: "name", Operator: NotEqual, Value: "ci-*"}.StringMatcher()
+	assert.NilError(t, err)
+	assert.Assert(t, !notGlob("ci-runner"))
+
+	re, err := Filter{Key: "name", Operator: Match, Value: `^\d+\.\d+$`}.StringMatcher()
+	assert.NilError(t, err)
+	assert.Assert(t, re("1.4"))
+	assert.Assert(t, !re("1.4-alpine"))
+
+	_, err = Filter{Key: "name", Operator: Less, Value: "a"}.StringMatcher()
+	assert.Error(t, err, `invalid filter "name<a": operator "<" is not supported for "name"`)
+}
+
+func TestSizeMatcher(t *testing.T) {
+	m, err := Filter{Key: "size", Operator: Greater, Value: "100MB"}.SizeMatcher()
+	assert.NilError(t, err)
+	assert.Assert(t, m(100*1000*1000+1))
+	assert.Assert(t, !m(100*1000*1000))
+}
+
+func TestTimeMatcher(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	before, err := Filter{Key: "pulled", Operator: Less, Value: "30d"}.TimeMatcher(now)
+	assert.NilError(t, err)
+	assert.Assert(t, before(now.Add(-31*24*time.Hour)))
+	assert.Assert(t, !before(now.Add(-29*24*time.Hour)))
+	assert.Assert(t, before(time.Time{}))
+
+	after, err := Filter{Key: "pushed", Operator: Greater, Value: "2024-01-01"}.TimeMatcher(now)
+	assert.NilError(t, err)
+	assert.Assert(t, after(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Assert(t, !after(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Assert(t, !after(time.Time{}))
+}
+
+func TestTimeMatcherBoundary(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	boundary := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		operator Operator
+		before   bool
+		at       bool
+		after    bool
+		never    bool
+	}{
+		{operator: Less, before: true, at: false, after: false, never: true},
+		{operator: LessOrEqual, before: true, at: true, after: false, never: true},
+		{operator: Greater, before: false, at: false, after: true, never: false},
+		{operator: GreaterOrEqual, before: false, at: true, after: true, never: false},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.operator), func(t *testing.T) {
+			m, err := Filter{Key: "pushed", Operator: tc.operator, Value: "2024-01-01"}.TimeMatcher(now)
+			assert.NilError(t, err)
+			assert.Equal(t, m(boundary.Add(-time.Second)), tc.before)
+			assert.Equal(t, m(boundary), tc.at)
+			assert.Equal(t, m(boundary.Add(time.Second)), tc.after)
+			assert.Equal(t, m(time.Time{}), tc.never)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"12h": 12 * time.Hour,
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"1y":  365 * 24 * time.Hour,
+	} {
+		d, err := ParseDuration(s)
+		assert.NilError(t, err)
+		assert.Equal(t, d, expected)
+	}
+	_, err := ParseDuration("soon")
+	assert.Error(t, err, `invalid duration "soon"`)
+}
+
+func TestParseExpiration(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt, err := ParseExpiration("30d", now)
+	assert.NilError(t, err)
+	assert.Equal(t, expiresAt, now.Add(30*24*time.Hour))
+
+	expiresAt, err = ParseExpiration("", now)
+	assert.NilError(t, err)
+	assert.Assert(t, expiresAt.IsZero())
+
+	_, err = ParseExpiration("0d", now)
+	assert.Error(t, err, `invalid expiration "0d": should be a positive duration`)
+	_, err = ParseExpiration("soon", now)
+	assert.Error(t, err, `invalid duration "soon"`)
+}
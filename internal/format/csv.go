@@ -0,0 +1,45 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package format
+
+import (
+	"encoding/csv"
+	"io"
+	"regexp"
+)
+
+// escapeSequences matches the colors and hyperlinks of the pretty print format
+var escapeSequences = regexp.MustCompile("\x1b\\]8;;[^\x07]*\x07|\x1b\\[[0-9;]*m")
+
+func printCSV(out io.Writer, separator rune, header []string, rows [][]string) error {
+	w := csv.NewWriter(out)
+	w.Comma = separator
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = escapeSequences.ReplaceAllString(value, "")
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
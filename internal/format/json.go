@@ -22,6 +22,8 @@ import (
 	"io"
 
 	"github.com/spf13/pflag"
+
+	"github.com/docker/hub-tool/internal/errdef"
 )
 
 // Option handles format flags and printing the values depending the format
@@ -32,23 +34,55 @@ type Option struct {
 // PrettyPrinter prints all the values in a pretty print format
 type PrettyPrinter func(io.Writer, interface{}) error
 
+// Columns returns the header and the rows of the table printing the values,
+// used by the csv and tsv formats
+type Columns func(interface{}) ([]string, [][]string)
+
 // AddFormatFlag add the format flag to a command
 func (o *Option) AddFormatFlag(flags *pflag.FlagSet) {
-	flags.StringVar(&o.format, "format", "", `Print values using a custom format ("json")`)
+	flags.StringVar(&o.format, "format", "", `Print values using a custom format ("json"|"yaml"|"ndjson"|"csv"|"tsv") or a Go template (e.g.: "table {{.Name}}")`)
 }
 
 // Print outputs values depending the given format
 func (o *Option) Print(out io.Writer, values interface{}, prettyPrinter PrettyPrinter) error {
+	return o.PrintTable(out, values, prettyPrinter, nil)
+}
+
+// PrintTable outputs values depending the given format, the columns
+// enabling the csv and tsv formats
+func (o *Option) PrintTable(out io.Writer, values interface{}, prettyPrinter PrettyPrinter, columns Columns) error {
 	switch o.format {
 	case "":
 		return prettyPrinter(out, values)
 	case "json":
 		return printJSON(out, values)
+	case "yaml":
+		return printYAML(out, values)
+	case "ndjson":
+		return printNDJSON(out, values)
+	case "csv", "tsv":
+		if columns == nil {
+			return errdef.Validation(fmt.Errorf("format %q is only supported when listing elements", o.format))
+		}
+		header, rows := columns(values)
+		separator := ','
+		if o.format == "tsv" {
+			separator = '\t'
+		}
+		return printCSV(out, separator, header, rows)
 	default:
-		return fmt.Errorf("unsupported format type: %q", o.format)
+		if IsTemplate(o.format) {
+			return Template(out, o.format, values)
+		}
+		return errdef.Validation(fmt.Errorf("unsupported format type: %q", o.format))
 	}
 }
 
+// IsStreaming returns true if the values can be printed page by page, as soon as they're fetched
+func (o *Option) IsStreaming() bool {
+	return o.format == "ndjson"
+}
+
 func printJSON(out io.Writer, values interface{}) error {
 	data, err := json.MarshalIndent(values, "", "  ")
 	if err != nil {
@@ -0,0 +1,141 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+type element struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Private bool     `json:"private"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+var elements = []element{
+	{Name: "user/repo", Version: "1.0", Tags: []string{"latest", "true"}},
+	{Name: "user/other", Version: "2", Private: true},
+}
+
+func TestPrintYAML(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	opts := Option{format: "yaml"}
+	assert.NilError(t, opts.Print(out, elements, nil))
+	assert.Equal(t, out.String(), `- name: user/repo
+  version: "1.0"
+  private: false
+  tags:
+    - latest
+    - "true"
+- name: user/other
+  version: "2"
+  private: true
+`)
+}
+
+func TestPrintNDJSON(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	opts := Option{format: "ndjson"}
+	assert.Assert(t, opts.IsStreaming())
+	assert.NilError(t, opts.Print(out, elements, nil))
+	assert.Equal(t, out.String(), `{"name":"user/repo","version":"1.0","private":false,"tags":["latest","true"]}
+{"name":"user/other","version":"2","private":true}
+`)
+}
+
+func elementColumns(values interface{}) ([]string, [][]string) {
+	var rows [][]string
+	for _, e := range values.([]element) {
+		rows = append(rows, []string{"\x1b]8;;https://hub.docker.com\x07" + e.Name + "\x1b]8;;\x07", "\x1b[34m" + e.Version + "\x1b[0m", "a, \"b\""})
+	}
+	return []string{"NAME", "VERSION", "NOTE"}, rows
+}
+
+func TestPrintCSV(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	opts := Option{format: "csv"}
+	assert.NilError(t, opts.PrintTable(out, elements, nil, elementColumns))
+	assert.Equal(t, out.String(), `NAME,VERSION,NOTE
+user/repo,1.0,"a, ""b"""
+user/other,2,"a, ""b"""
+`)
+
+	out.Reset()
+	opts = Option{format: "tsv"}
+	assert.NilError(t, opts.PrintTable(out, elements, nil, elementColumns))
+	assert.Equal(t, out.String(), "NAME\tVERSION\tNOTE\nuser/repo\t1.0\t\"a, \"\"b\"\"\"\nuser/other\t2\t\"a, \"\"b\"\"\"\n")
+
+	err := opts.Print(out, elements, nil)
+	assert.ErrorContains(t, err, `format "tsv" is only supported when listing elements`)
+}
+
+func TestPrintListEnvelope(t *testing.T) {
+	list := List{Kind: "element", Total: 5, Values: elements, Items: elements}
+	out := bytes.NewBuffer(nil)
+	opts := Option{format: "json"}
+	assert.NilError(t, opts.PrintList(out, list, nil, nil))
+	assert.Equal(t, out.String(), `{
+  "schema_version": "1",
+  "kind": "element",
+  "total": 5,
+  "items": [
+    {
+      "name": "user/repo",
+      "version": "1.0",
+      "private": false,
+      "tags": [
+        "latest",
+        "true"
+      ]
+    },
+    {
+      "name": "user/other",
+      "version": "2",
+      "private": true
+    }
+  ]
+}
+`)
+
+	out.Reset()
+	opts = Option{format: "ndjson"}
+	assert.NilError(t, opts.PrintList(out, list, nil, nil))
+	assert.Equal(t, strings.Count(out.String(), "\n"), 2)
+
+	out.Reset()
+	opts = Option{format: "{{.Name}}"}
+	assert.NilError(t, opts.PrintList(out, list, nil, nil))
+	assert.Equal(t, out.String(), "user/repo\nuser/other\n")
+}
+
+func TestPrintResourceEnvelope(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	opts := Option{format: "yaml"}
+	assert.NilError(t, opts.PrintResource(out, Resource{Kind: "element", Value: elements[1], Item: elements[1]}, nil))
+	assert.Equal(t, out.String(), `schema_version: "1"
+kind: element
+item:
+  name: user/other
+  version: "2"
+  private: true
+`)
+}
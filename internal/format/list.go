@@ -0,0 +1,101 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package format
+
+import (
+	"io"
+)
+
+// SchemaVersion is the version of the JSON representation of the resources,
+// increased on breaking changes
+const SchemaVersion = "1"
+
+// List is a list of resources
+type List struct {
+	// Kind is the type of the resources, like "tag"
+	Kind string
+	// Total is the number of resources, more than the values if only a page was fetched
+	Total int
+	// Values are printed by the pretty printer, the templates and the columns
+	Values interface{}
+	// Items are the documented representation of the values, printed by the json, yaml and ndjson formats
+	Items interface{}
+}
+
+// Resource is a single resource
+type Resource struct {
+	// Kind is the type of the resource, like "token"
+	Kind string
+	// Value is printed by the pretty printer and the templates
+	Value interface{}
+	// Item is the documented representation of the value, printed by the json, yaml and ndjson formats
+	Item interface{}
+}
+
+type listEnvelope struct {
+	SchemaVersion string      `json:"schema_version"`
+	Kind          string      `json:"kind"`
+	Total         int         `json:"total"`
+	Items         interface{} `json:"items"`
+}
+
+type resourceEnvelope struct {
+	SchemaVersion string      `json:"schema_version"`
+	Kind          string      `json:"kind"`
+	Item          interface{} `json:"item"`
+}
+
+// PrintList outputs a list of resources depending the given format, wrapping
+// the items in an envelope with the total and the schema version in json and yaml
+func (o *Option) PrintList(out io.Writer, list List, prettyPrinter PrettyPrinter, columns Columns) error {
+	envelope := listEnvelope{
+		SchemaVersion: SchemaVersion,
+		Kind:          list.Kind,
+		Total:         list.Total,
+		Items:         list.Items,
+	}
+	switch o.format {
+	case "json":
+		return printJSON(out, envelope)
+	case "yaml":
+		return printYAML(out, envelope)
+	case "ndjson":
+		return printNDJSON(out, list.Items)
+	default:
+		return o.PrintTable(out, list.Values, prettyPrinter, columns)
+	}
+}
+
+// PrintResource outputs a resource depending the given format, wrapping the
+// item in an envelope with the schema version in json and yaml
+func (o *Option) PrintResource(out io.Writer, resource Resource, prettyPrinter PrettyPrinter) error {
+	envelope := resourceEnvelope{
+		SchemaVersion: SchemaVersion,
+		Kind:          resource.Kind,
+		Item:          resource.Item,
+	}
+	switch o.format {
+	case "json":
+		return printJSON(out, envelope)
+	case "yaml":
+		return printYAML(out, envelope)
+	case "ndjson":
+		return printNDJSON(out, resource.Item)
+	default:
+		return o.Print(out, resource.Value, prettyPrinter)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tabwriter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/pflag"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+// Options handles the flags selecting and truncating the columns of a table
+type Options struct {
+	columns []string
+	noTrunc bool
+}
+
+// AddColumnsFlags adds the column selection flags to a command, listing the
+// available columns in the help
+func (o *Options) AddColumnsFlags(flags *pflag.FlagSet, headers []string) {
+	names := make([]string, 0, len(headers))
+	for _, h := range headers {
+		names = append(names, ColumnName(h))
+	}
+	flags.StringSliceVar(&o.columns, "columns", nil, fmt.Sprintf("Select the columns to print (%s)", strings.Join(names, ",")))
+	flags.BoolVar(&o.noTrunc, "no-trunc", false, "Don't truncate the columns to fit in the terminal")
+}
+
+// New creates a new tab writer, truncating the columns unless --no-trunc is set
+func (o *Options) New(w io.Writer, padding string) TabWriter {
+	if o.noTrunc {
+		return NewWithWidth(w, padding, 0)
+	}
+	return New(w, padding)
+}
+
+// NewFixed creates a new tab writer with fixed column widths, the values
+// widening their column instead of being truncated if --no-trunc is set
+func (o *Options) NewFixed(w io.Writer, padding string, widths []int) TabWriter {
+	return newFixed(w, padding, widths, o.noTrunc)
+}
+
+// SelectColumns returns the columns selected with --columns in the given
+// order, or the default ones, the optional columns being opt-in
+func SelectColumns[C any](o *Options, defaults []C, optional []C, header func(C) string) ([]C, error) {
+	if len(o.columns) == 0 {
+		return defaults, nil
+	}
+	all := append(append([]C{}, defaults...), optional...)
+	var selected []C
+	for _, name := range o.columns {
+		found := false
+		for _, c := range all {
+			if normalizeColumn(name) == normalizeColumn(header(c)) {
+				selected = append(selected, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			names := make([]string, 0, len(all))
+			for _, c := range all {
+				names = append(names, ColumnName(header(c)))
+			}
+			return nil, errdef.Validation(fmt.Errorf("unknown column %q: should be one of %s", name, strings.Join(names, ", ")))
+		}
+	}
+	return selected, nil
+}
+
+// Headers returns the headers of the columns
+func Headers[C any](header func(C) string, columns ...[]C) []string {
+	var headers []string
+	for _, cs := range columns {
+		for _, c := range cs {
+			headers = append(headers, header(c))
+		}
+	}
+	return headers
+}
+
+// Records returns the header and the rows of the values for the given columns,
+// as printed by the csv and tsv formats
+func Records[C, V any](columns []C, header func(C) string, value func(C, V) string) func(interface{}) ([]string, [][]string) {
+	return func(values interface{}) ([]string, [][]string) {
+		headers := Headers(header, columns)
+		var rows [][]string
+		for _, v := range values.([]V) {
+			row := make([]string, 0, len(columns))
+			for _, c := range columns {
+				row = append(row, value(c, v))
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows
+	}
+}
+
+// ColumnName returns the name of a column in --columns, "last-update" for "LAST UPDATE"
+func ColumnName(header string) string {
+	return strings.ToLower(strings.ReplaceAll(header, " ", "-"))
+}
+
+// normalizeColumn lets "last-update", "last_update" and "LastUpdate" match the "LAST UPDATE" header
+func normalizeColumn(name string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
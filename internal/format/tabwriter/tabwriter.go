@@ -19,14 +19,28 @@ package tabwriter
 import (
 	"fmt"
 	"io"
+	"os"
+
+	"github.com/moby/term"
+)
+
+const (
+	ellipsis = "…"
+	// minWidth is the width under which a column is never truncated
+	minWidth = 8
 )
 
 type tw struct {
-	idx     int
-	lines   [][]string
-	widths  [][]int
-	w       io.Writer
-	padding string
+	idx      int
+	lines    [][]string
+	widths   [][]int
+	w        io.Writer
+	padding  string
+	maxWidth int
+	// fixed are the widths of the columns whatever their values, 0 for a column as wide as its values
+	fixed []int
+	// growFixed lets the values wider than their fixed column widen it instead of being truncated
+	growFixed bool
 }
 
 // TabWriter interface :)
@@ -36,14 +50,41 @@ type TabWriter interface {
 	Flush() error
 }
 
-// New creates a new tab writer that output to the io.Writer
+// New creates a new tab writer that output to the io.Writer, truncating the
+// columns to fit in the width of the terminal if the output is one
 func New(w io.Writer, padding string) TabWriter {
+	return NewWithWidth(w, padding, terminalWidth(w))
+}
+
+// NewWithWidth creates a new tab writer truncating the columns to fit in
+// maxWidth characters, 0 meaning no limit
+func NewWithWidth(w io.Writer, padding string, maxWidth int) TabWriter {
+	return &tw{
+		idx:      0,
+		lines:    [][]string{},
+		widths:   [][]int{},
+		w:        w,
+		padding:  padding,
+		maxWidth: maxWidth,
+	}
+}
+
+// NewFixed creates a new tab writer whose columns have the given widths
+// whatever their values, so that a table printed in several parts stays
+// aligned. Longer values are truncated, a width of 0 leaves a column as wide
+// as its values.
+func NewFixed(w io.Writer, padding string, widths []int) TabWriter {
+	return newFixed(w, padding, widths, false)
+}
+
+func newFixed(w io.Writer, padding string, widths []int, grow bool) *tw {
 	return &tw{
-		idx:     0,
-		lines:   [][]string{},
-		widths:  [][]int{},
-		w:       w,
-		padding: padding,
+		lines:     [][]string{},
+		widths:    [][]int{},
+		w:         w,
+		padding:   padding,
+		fixed:     widths,
+		growFixed: grow,
 	}
 }
 
@@ -64,11 +105,20 @@ func (t *tw) Line() {
 func (t *tw) Flush() error {
 	maxs := []int{}
 	for i := range t.lines[0] {
-		maxs = append(maxs, t.max(i))
+		m := t.max(i)
+		if i < len(t.fixed) && t.fixed[i] > 0 && (m < t.fixed[i] || !t.growFixed) {
+			m = t.fixed[i]
+		}
+		maxs = append(maxs, m)
 	}
+	t.shrink(maxs)
 
 	for k, l := range t.lines {
 		for i, c := range l {
+			if i < len(maxs) && t.widths[k][i] > maxs[i] {
+				c = truncate(c, maxs[i])
+				t.widths[k][i] = maxs[i]
+			}
 			pad := ""
 			if i != 0 {
 				pad = t.pad(maxs[i-1], t.widths[k][i-1])
@@ -85,6 +135,30 @@ func (t *tw) Flush() error {
 	return nil
 }
 
+// shrink reduces the widest columns until the table fits in the maximum width
+func (t *tw) shrink(maxs []int) {
+	if t.maxWidth <= 0 {
+		return
+	}
+	total := len(t.padding) * (len(maxs) - 1)
+	for _, m := range maxs {
+		total += m
+	}
+	for total > t.maxWidth {
+		widest := -1
+		for i, m := range maxs {
+			if m > minWidth && (widest < 0 || m > maxs[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		maxs[widest]--
+		total--
+	}
+}
+
 func (t *tw) pad(max int, l int) string {
 	ret := t.padding
 	for i := 0; i < max-l; i++ {
@@ -106,3 +180,84 @@ func (t *tw) max(col int) int {
 	}
 	return w
 }
+
+// truncate cuts the visible text of a value to the width, ending it with an
+// ellipsis and keeping the escape sequences of colors and hyperlinks
+func truncate(s string, width int) string {
+	var (
+		result  []rune
+		visible int
+		cut     bool
+	)
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\x1b' {
+			end := escapeSequenceEnd(runes, i)
+			result = append(result, runes[i:end]...)
+			i = end - 1
+			continue
+		}
+		if cut {
+			continue
+		}
+		if visible == width-1 {
+			result = append(result, []rune(ellipsis)...)
+			cut = true
+			continue
+		}
+		result = append(result, runes[i])
+		visible++
+	}
+	return string(result)
+}
+
+// escapeSequenceEnd returns the index following an ANSI color or OSC hyperlink sequence
+func escapeSequenceEnd(runes []rune, start int) int {
+	if start+1 >= len(runes) {
+		return len(runes)
+	}
+	switch runes[start+1] {
+	case ']':
+		// OSC sequences end with BEL
+		for i := start + 2; i < len(runes); i++ {
+			if runes[i] == '\a' {
+				return i + 1
+			}
+		}
+	case '[':
+		for i := start + 2; i < len(runes); i++ {
+			if runes[i] >= '@' && runes[i] <= '~' {
+				return i + 1
+			}
+		}
+	}
+	return len(runes)
+}
+
+type terminal interface {
+	IsTerminal() bool
+	GetTtySize() (uint, uint)
+}
+
+// terminalWidth returns the width of the terminal the writer outputs to, or 0
+func terminalWidth(w io.Writer) int {
+	switch out := w.(type) {
+	case terminal:
+		if !out.IsTerminal() {
+			return 0
+		}
+		_, width := out.GetTtySize()
+		return int(width)
+	case *os.File:
+		if !term.IsTerminal(out.Fd()) {
+			return 0
+		}
+		size, err := term.GetWinsize(out.Fd())
+		if err != nil {
+			return 0
+		}
+		return int(size.Width)
+	default:
+		return 0
+	}
+}
@@ -18,6 +18,7 @@ package tabwriter
 
 import (
 	"bytes"
+	"strconv"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -48,3 +49,82 @@ func TestTwoLines(t *testing.T) {
 	assert.NilError(t, err)
 	golden.Assert(t, b.String(), "twolines.golden")
 }
+
+func TestTruncateToWidth(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	tw := NewWithWidth(b, "  ", 30)
+	for _, line := range [][]string{
+		{"NAME", "DESCRIPTION", "PULLS"},
+		{"user/repo", "a very long description which does not fit", "42"},
+		{"user/other", "short", "7"},
+	} {
+		for _, c := range line {
+			tw.Column(c, len(c))
+		}
+		tw.Line()
+	}
+	assert.NilError(t, tw.Flush())
+	assert.Equal(t, b.String(), "NAME        DESCRIPTION  PULLS\n"+
+		"user/repo   a very lon…  42\n"+
+		"user/other  short        7\n")
+}
+
+func TestFixedWidths(t *testing.T) {
+	render := func(grow bool, lines ...[]string) string {
+		b := bytes.NewBuffer(nil)
+		tw := newFixed(b, "  ", []int{6, 8, 0}, grow)
+		for _, line := range lines {
+			for _, c := range line {
+				tw.Column(c, len(c))
+			}
+			tw.Line()
+		}
+		assert.NilError(t, tw.Flush())
+		return b.String()
+	}
+	assert.Equal(t, render(false, []string{"TIME", "ACTION", "NAME"}), "TIME    ACTION    NAME\n")
+	assert.Equal(t, render(false, []string{"12:00", "repo.create", "user/repository"}), "12:00   repo.cr…  user/repository\n")
+	assert.Equal(t, render(true, []string{"12:00", "repo.create", "user/repository"}), "12:00   repo.create  user/repository\n")
+}
+
+func TestTruncateKeepsEscapeSequences(t *testing.T) {
+	link := "\x1b]8;;https://hub.docker.com\x07user/repository\x1b]8;;\x07"
+	assert.Equal(t, truncate(link, 8), "\x1b]8;;https://hub.docker.com\x07user/re…\x1b]8;;\x07")
+	assert.Equal(t, truncate("\x1b[34mDESCRIPTION\x1b[0m", 5), "\x1b[34mDESC…\x1b[0m")
+}
+
+func TestSelectColumns(t *testing.T) {
+	type column struct{ Header string }
+	header := func(c column) string { return c.Header }
+	defaults := []column{{"REPOSITORY"}, {"LAST UPDATE"}, {"PULLS"}}
+	optional := []column{{"NAMESPACE"}}
+
+	var opts Options
+	columns, err := SelectColumns(&opts, defaults, optional, header)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, columns, defaults)
+
+	opts.columns = []string{"namespace", "last_update", "Repository"}
+	columns, err = SelectColumns(&opts, defaults, optional, header)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, columns, []column{{"NAMESPACE"}, {"LAST UPDATE"}, {"REPOSITORY"}})
+
+	opts.columns = []string{"stars"}
+	_, err = SelectColumns(&opts, defaults, optional, header)
+	assert.Error(t, err, `unknown column "stars": should be one of repository, last-update, pulls, namespace`)
+}
+
+func TestRecords(t *testing.T) {
+	type column struct {
+		Header string
+		value  func(string) string
+	}
+	columns := []column{
+		{"NAME", func(s string) string { return s }},
+		{"LENGTH", func(s string) string { return strconv.Itoa(len(s)) }},
+	}
+	records := Records(columns, func(c column) string { return c.Header }, func(c column, s string) string { return c.value(s) })
+	header, rows := records([]string{"ubuntu", "alpine:3"})
+	assert.DeepEqual(t, header, []string{"NAME", "LENGTH"})
+	assert.DeepEqual(t, rows, [][]string{{"ubuntu", "6"}, {"alpine:3", "8"}})
+}
@@ -0,0 +1,182 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/docker/go-units"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/internal/format/tabwriter"
+)
+
+const tableDirective = "table"
+
+var (
+	// fieldReference matches the first field used by a template action, like "Name" in "{{.Name}}" or "Size" in "{{humanSize .Size}}"
+	fieldReference = regexp.MustCompile(`{{[^}]*?\.([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*)[^}]*}}`)
+
+	templateFuncs = template.FuncMap{
+		"json":          toJSON,
+		"humanSize":     humanSize,
+		"humanDuration": humanDuration,
+		"join":          strings.Join,
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+	}
+)
+
+// IsTemplate returns true if the format is a Go template
+func IsTemplate(format string) bool {
+	return strings.Contains(format, "{{")
+}
+
+// Template prints the values using a Go template, once per element if the
+// values are a slice. Prefixed with "table", the output is aligned in
+// columns with a header.
+func Template(out io.Writer, format string, values interface{}) error {
+	table := false
+	if strings.HasPrefix(format, tableDirective+" ") {
+		table = true
+		format = strings.TrimPrefix(format, tableDirective+" ")
+	}
+	format = unescape(format)
+	tmpl, err := template.New("").Funcs(templateFuncs).Parse(format)
+	if err != nil {
+		return errdef.Validation(fmt.Errorf("invalid template: %s", err))
+	}
+
+	var elements []interface{}
+	v := reflect.ValueOf(values)
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, v.Index(i).Interface())
+		}
+	} else {
+		elements = append(elements, values)
+	}
+
+	if !table {
+		for _, e := range elements {
+			if err := tmpl.Execute(out, e); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.New(out, "    ")
+	for _, header := range strings.Split(templateHeader(format), "\t") {
+		tw.Column(ansi.Header(header), len(header))
+	}
+	tw.Line()
+	for _, e := range elements {
+		buf := bytes.NewBuffer(nil)
+		if err := tmpl.Execute(buf, e); err != nil {
+			return err
+		}
+		for _, c := range strings.Split(buf.String(), "\t") {
+			tw.Column(c, len(c))
+		}
+		tw.Line()
+	}
+	return tw.Flush()
+}
+
+// templateHeader builds the table header from the fields used in the
+// template, "{{.Name}}\t{{.PullCount}}" giving "NAME\tPULL COUNT"
+func templateHeader(format string) string {
+	return fieldReference.ReplaceAllStringFunc(format, func(action string) string {
+		fields := strings.Split(fieldReference.FindStringSubmatch(action)[1], ".")
+		return headerName(fields[len(fields)-1])
+	})
+}
+
+func headerName(field string) string {
+	var words []string
+	start := 0
+	runes := []rune(field)
+	for i := 1; i < len(runes); i++ {
+		// Split "PullCount" and "LastUpdated", but keep acronyms like "UUID" or "ID"
+		if unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+	return strings.ToUpper(strings.Join(words, " "))
+}
+
+// unescape interprets the tab and newline escape sequences given on the command line
+func unescape(format string) string {
+	return strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+}
+
+func toJSON(v interface{}) (string, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func humanSize(v interface{}) (string, error) {
+	switch size := v.(type) {
+	case int:
+		return units.HumanSize(float64(size)), nil
+	case int64:
+		return units.HumanSize(float64(size)), nil
+	case float64:
+		return units.HumanSize(size), nil
+	default:
+		return "", fmt.Errorf("humanSize: unsupported type %T", v)
+	}
+}
+
+// humanDuration prints a duration, or the time elapsed since a date
+func humanDuration(v interface{}) (string, error) {
+	switch d := v.(type) {
+	case time.Duration:
+		return units.HumanDuration(d), nil
+	case time.Time:
+		if d.IsZero() {
+			return "", nil
+		}
+		return units.HumanDuration(time.Since(d)) + " ago", nil
+	case *time.Time:
+		if d == nil || d.IsZero() {
+			return "", nil
+		}
+		return units.HumanDuration(time.Since(*d)) + " ago", nil
+	default:
+		return "", fmt.Errorf("humanDuration: unsupported type %T", v)
+	}
+}
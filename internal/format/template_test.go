@@ -0,0 +1,101 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type repository struct {
+	Name       string
+	PullCount  int
+	Size       int64
+	Tags       []string
+	LastUpdate time.Time
+}
+
+func TestTemplate(t *testing.T) {
+	repositories := []repository{
+		{Name: "user/repo", PullCount: 42, Size: 2048, Tags: []string{"latest", "1.0"}},
+		{Name: "user/other-repository", PullCount: 7, Size: 1 << 20},
+	}
+
+	testCases := []struct {
+		name     string
+		format   string
+		values   interface{}
+		expected string
+	}{
+		{
+			name:     "fields per element",
+			format:   `{{.Name}}\t{{.PullCount}}`,
+			values:   repositories,
+			expected: "user/repo\t42\nuser/other-repository\t7\n",
+		},
+		{
+			name:     "single value",
+			format:   "{{.Name}}",
+			values:   &repositories[0],
+			expected: "user/repo\n",
+		},
+		{
+			name:     "helpers",
+			format:   `{{humanSize .Size}} {{join .Tags ","}} {{json .Tags}} {{humanDuration .LastUpdate}}`,
+			values:   repositories[:1],
+			expected: `2.048kB latest,1.0 ["latest","1.0"] ` + "\n",
+		},
+		{
+			name:   "table",
+			format: `table {{.Name}}\t{{.PullCount}}\t{{humanSize .Size}}`,
+			values: repositories,
+			expected: "NAME                     PULL COUNT    SIZE\n" +
+				"user/repo                42            2.048kB\n" +
+				"user/other-repository    7             1.049MB\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			assert.NilError(t, Template(out, tc.format, tc.values))
+			assert.Equal(t, out.String(), tc.expected)
+		})
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	assert.ErrorContains(t, Template(out, "{{.Name", repository{}), "invalid template")
+	assert.ErrorContains(t, Template(out, "{{.Unknown}}", repository{}), "can't evaluate field Unknown")
+}
+
+func TestHeaderName(t *testing.T) {
+	assert.Equal(t, headerName("Name"), "NAME")
+	assert.Equal(t, headerName("PullCount"), "PULL COUNT")
+	assert.Equal(t, headerName("UUID"), "UUID")
+	assert.Equal(t, headerName("ClientID"), "CLIENT ID")
+	assert.Equal(t, headerName("HTTPStatus"), "HTTP STATUS")
+}
+
+func TestPrintUnsupportedFormat(t *testing.T) {
+	opts := Option{format: "xml"}
+	err := opts.Print(bytes.NewBuffer(nil), nil, nil)
+	assert.ErrorContains(t, err, `unsupported format type: "xml"`)
+}
@@ -0,0 +1,60 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// printYAML prints the values as YAML, with the same keys as the json format
+func printYAML(out io.Writer, values interface{}) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	// JSON being valid YAML, decode it in nodes to keep the order of the fields
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// resetStyle switches the JSON flow style to the YAML block style, keeping
+// the quotes of strings which would otherwise be decoded as other types
+func resetStyle(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		node.Style = 0
+	} else {
+		node.Style &^= yaml.FlowStyle
+		if node.Style == yaml.DoubleQuotedStyle {
+			node.Style = 0
+		}
+	}
+	for _, n := range node.Content {
+		resetStyle(n)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package orgconfig describes an organization in a YAML file: its teams, their
+// members and the permissions of the teams on the repositories. The file is
+// compared to the live state of the organization on Hub to plan the changes
+// reconciling them.
+//
+// The values left out of the file are not managed: a team without members
+// keeps its current members, while an empty list of members removes them all.
+package orgconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/docker/hub-tool/internal/errdef"
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// Config is the desired state of an organization
+type Config struct {
+	Organization string       `yaml:"organization"`
+	Teams        []Team       `yaml:"teams"`
+	Repositories []Repository `yaml:"repositories"`
+}
+
+// Team is the desired state of a team, identified by its name. The members are Docker IDs.
+type Team struct {
+	Name        string   `yaml:"name"`
+	Description *string  `yaml:"description"`
+	Members     []string `yaml:"members"`
+}
+
+// Repository is the desired state of a repository of the organization, which
+// must already exist. The permissions map team names to "read", "write" or "admin".
+type Repository struct {
+	Name        string            `yaml:"name"`
+	Description *string           `yaml:"description"`
+	Private     *bool             `yaml:"private"`
+	Permissions map[string]string `yaml:"permissions"`
+}
+
+// Load reads and validates the configuration of an organization. The repository
+// names are qualified by the organization, like "myorg/app".
+func Load(r io.Reader) (*Config, error) {
+	var config Config
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errdef.Validation(errors.New("empty organization configuration"))
+		}
+		return nil, errdef.Validation(err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, errdef.Validation(err)
+	}
+	return &config, nil
+}
+
+func (c *Config) validate() error {
+	if c.Organization == "" {
+		return errors.New("missing organization")
+	}
+	teams := map[string]bool{}
+	for _, team := range c.Teams {
+		if team.Name == "" {
+			return errors.New("missing team name")
+		}
+		if teams[strings.ToLower(team.Name)] {
+			return fmt.Errorf("team %s is declared twice", team.Name)
+		}
+		teams[strings.ToLower(team.Name)] = true
+		members := map[string]bool{}
+		for _, member := range team.Members {
+			// Members are listed by username once they accept an invite sent by email,
+			// which would never match the email
+			if strings.Contains(member, "@") {
+				return fmt.Errorf("team %s: member %s should be a Docker ID, not an email", team.Name, member)
+			}
+			if members[strings.ToLower(member)] {
+				return fmt.Errorf("team %s: member %s is listed twice", team.Name, member)
+			}
+			members[strings.ToLower(member)] = true
+		}
+	}
+	repositories := map[string]bool{}
+	for i, repository := range c.Repositories {
+		name, err := c.qualify(repository.Name)
+		if err != nil {
+			return err
+		}
+		if repositories[name] {
+			return fmt.Errorf("repository %s is declared twice", name)
+		}
+		repositories[name] = true
+		c.Repositories[i].Name = name
+		// Hub team names are case insensitive
+		teams := map[string]string{}
+		for _, team := range sortedKeys(repository.Permissions) {
+			if other, ok := teams[strings.ToLower(team)]; ok {
+				return fmt.Errorf("repository %s: team %s is listed twice, as %s and %s", name, team, other, team)
+			}
+			teams[strings.ToLower(team)] = team
+			permission := repository.Permissions[team]
+			if !slices.Contains(hub.Permissions, permission) {
+				return fmt.Errorf("repository %s: invalid permission %q for team %s, should be one of %s", name, permission, team, strings.Join(hub.Permissions, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// qualify prefixes the repository name with the organization
+func (c *Config) qualify(repository string) (string, error) {
+	if repository == "" {
+		return "", errors.New("missing repository name")
+	}
+	namespace, name, ok := strings.Cut(strings.ToLower(repository), "/")
+	if !ok {
+		return strings.ToLower(c.Organization) + "/" + namespace, nil
+	}
+	if namespace != strings.ToLower(c.Organization) {
+		return "", fmt.Errorf("repository %s is not in organization %s", repository, c.Organization)
+	}
+	return namespace + "/" + name, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
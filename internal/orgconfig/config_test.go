@@ -0,0 +1,78 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package orgconfig
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+func TestLoad(t *testing.T) {
+	config, err := Load(strings.NewReader(`organization: myorg
+teams:
+  - name: developers
+    description: Build the images
+    members: [alice, bob]
+  - name: qa
+  - name: interns
+    members: []
+repositories:
+  - name: app
+    private: true
+    permissions:
+      developers: write
+  - name: myorg/tools
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, config.Organization, "myorg")
+	assert.Equal(t, *config.Teams[0].Description, "Build the images")
+	assert.DeepEqual(t, config.Teams[0].Members, []string{"alice", "bob"})
+	assert.Assert(t, config.Teams[1].Description == nil)
+	assert.Assert(t, config.Teams[1].Members == nil, "members are not managed when left out")
+	assert.Assert(t, config.Teams[2].Members != nil && len(config.Teams[2].Members) == 0, "an empty list removes the members")
+	assert.Equal(t, config.Repositories[0].Name, "myorg/app")
+	assert.Equal(t, *config.Repositories[0].Private, true)
+	assert.Equal(t, config.Repositories[1].Name, "myorg/tools")
+	assert.Assert(t, config.Repositories[1].Permissions == nil)
+}
+
+func TestLoadErrors(t *testing.T) {
+	testCases := []struct {
+		config   string
+		expected string
+	}{
+		{"", "empty organization configuration"},
+		{"teams: []", "missing organization"},
+		{"organization: myorg\nmembers: [alice]", "field members not found"},
+		{"organization: myorg\nteams:\n  - name: dev\n  - name: Dev", "team Dev is declared twice"},
+		{"organization: myorg\nteams:\n  - name: dev\n    members: [alice, Alice]", "team dev: member Alice is listed twice"},
+		{"organization: myorg\nteams:\n  - name: dev\n    members: [bob@example.com]", "team dev: member bob@example.com should be a Docker ID, not an email"},
+		{"organization: myorg\nrepositories:\n  - name: other/app", "repository other/app is not in organization myorg"},
+		{"organization: myorg\nrepositories:\n  - name: app\n  - name: myorg/app", "repository myorg/app is declared twice"},
+		{"organization: myorg\nrepositories:\n  - name: app\n    permissions: {dev: read, Dev: write}", "repository myorg/app: team dev is listed twice, as Dev and dev"},
+		{"organization: myorg\nrepositories:\n  - name: app\n    permissions:\n      dev: push", `repository myorg/app: invalid permission "push" for team dev, should be one of read, write, admin`},
+	}
+	for _, tc := range testCases {
+		_, err := Load(strings.NewReader(tc.config))
+		assert.ErrorContains(t, err, tc.expected)
+		assert.Assert(t, errdef.IsValidation(err))
+	}
+}
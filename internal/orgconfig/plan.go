@@ -0,0 +1,288 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package orgconfig
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// Actions of the changes
+const (
+	ActionAdd    = "add"
+	ActionChange = "change"
+	ActionRemove = "remove"
+)
+
+// ownersTeam can't be deleted
+const ownersTeam = "owners"
+
+// Change is a step reconciling the organization with its configuration
+type Change struct {
+	Action      string `json:"action"`
+	Resource    string `json:"resource"`
+	Description string `json:"description"`
+
+	apply func(a *applier) error
+}
+
+// Plan lists the changes reconciling an organization with its configuration, in
+// the order they are applied
+type Plan struct {
+	Organization string   `json:"organization"`
+	Changes      []Change `json:"changes"`
+
+	teamIDs map[string]int
+}
+
+// NewPlan compares the configuration to the live state of the organization.
+// The teams missing from the configuration are only deleted when pruning.
+func NewPlan(config *Config, state *State, prune bool) (*Plan, error) {
+	plan := &Plan{
+		Organization: config.Organization,
+		Changes:      []Change{},
+		teamIDs:      map[string]int{},
+	}
+	for name, team := range state.Teams {
+		plan.teamIDs[name] = team.ID
+	}
+	declared := map[string]bool{}
+	for _, team := range config.Teams {
+		declared[strings.ToLower(team.Name)] = true
+		plan.planTeam(team, state)
+	}
+	for _, repository := range config.Repositories {
+		if err := plan.planRepository(repository, state, declared); err != nil {
+			return nil, err
+		}
+	}
+	if prune {
+		var names []string
+		for name := range state.Teams {
+			if !declared[name] && name != ownersTeam {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			team := state.Teams[name].Name
+			plan.add(ActionRemove, "team "+team, fmt.Sprintf("delete team %s", team), func(a *applier) error {
+				return a.hubClient.RemoveTeam(a.organization, team)
+			})
+		}
+	}
+	return plan, nil
+}
+
+// Count returns the number of changes of an action
+func (p *Plan) Count(action string) int {
+	count := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Apply applies the changes in order, calling applied after each one.
+// It stops at the first failure.
+func (p *Plan) Apply(hubClient *hub.Client, applied func(Change)) error {
+	a := &applier{
+		hubClient:    hubClient,
+		organization: p.Organization,
+		teamIDs:      p.teamIDs,
+	}
+	for i, c := range p.Changes {
+		if err := c.apply(a); err != nil {
+			return fmt.Errorf("failed to %s: %w (%d of %d changes applied)", c.Description, err, i, len(p.Changes))
+		}
+		applied(c)
+	}
+	return nil
+}
+
+func (p *Plan) add(action, resource, description string, apply func(a *applier) error) {
+	p.Changes = append(p.Changes, Change{Action: action, Resource: resource, Description: description, apply: apply})
+}
+
+func (p *Plan) planTeam(team Team, state *State) {
+	name := team.Name
+	resource := "team " + name
+	live, exists := state.Teams[strings.ToLower(name)]
+	switch {
+	case !exists:
+		description := ""
+		if team.Description != nil {
+			description = *team.Description
+		}
+		p.add(ActionAdd, resource, fmt.Sprintf("create team %s", name), func(a *applier) error {
+			created, err := a.hubClient.CreateTeam(a.organization, name, description)
+			if err != nil {
+				return err
+			}
+			a.teamIDs[strings.ToLower(name)] = created.ID
+			return nil
+		})
+	case team.Description != nil && *team.Description != live.Description:
+		description := *team.Description
+		p.add(ActionChange, resource, fmt.Sprintf("set the description of team %s to %q", name, description), func(a *applier) error {
+			_, err := a.hubClient.UpdateTeam(a.organization, name, nil, &description)
+			return err
+		})
+	}
+	if team.Members == nil {
+		return
+	}
+
+	current := map[string]bool{}
+	for _, member := range live.Members {
+		current[strings.ToLower(member.Username)] = true
+	}
+	desired := map[string]bool{}
+	for _, member := range team.Members {
+		member := member
+		desired[strings.ToLower(member)] = true
+		switch {
+		case current[strings.ToLower(member)]:
+		case state.Members[strings.ToLower(member)]:
+			p.add(ActionAdd, resource, fmt.Sprintf("add %s to team %s", member, name), func(a *applier) error {
+				return a.hubClient.AddTeamMember(a.organization, name, member)
+			})
+		case isInvited(state.Invites, member, name):
+			// Joins the team once the invite is accepted
+		default:
+			p.add(ActionAdd, resource, fmt.Sprintf("invite %s to team %s", member, name), func(a *applier) error {
+				return a.invite(name, member)
+			})
+		}
+	}
+	for _, member := range live.Members {
+		username := member.Username
+		if !desired[strings.ToLower(username)] {
+			p.add(ActionRemove, resource, fmt.Sprintf("remove %s from team %s", username, name), func(a *applier) error {
+				return a.hubClient.RemoveTeamMember(a.organization, name, username)
+			})
+		}
+	}
+}
+
+func (p *Plan) planRepository(repository Repository, state *State, declared map[string]bool) error {
+	name := repository.Name
+	resource := "repository " + name
+	live, ok := state.Repositories[name]
+	if !ok {
+		return fmt.Errorf("repository %s doesn't exist", name)
+	}
+	if repository.Description != nil && *repository.Description != live.Description {
+		description := *repository.Description
+		p.add(ActionChange, resource, fmt.Sprintf("set the description of repository %s to %q", name, description), func(a *applier) error {
+			return a.hubClient.UpdateRepository(name, description)
+		})
+	}
+	if repository.Private != nil && *repository.Private != live.IsPrivate {
+		private := *repository.Private
+		visibility := "public"
+		if private {
+			visibility = "private"
+		}
+		p.add(ActionChange, resource, fmt.Sprintf("make repository %s %s", name, visibility), func(a *applier) error {
+			return a.hubClient.SetRepositoryPrivacy(name, private)
+		})
+	}
+	if repository.Permissions == nil {
+		return nil
+	}
+
+	for _, team := range sortedKeys(repository.Permissions) {
+		team := team
+		permission := repository.Permissions[team]
+		if _, exists := state.Teams[strings.ToLower(team)]; !exists && !declared[strings.ToLower(team)] {
+			return fmt.Errorf("repository %s: team %s doesn't exist", name, team)
+		}
+		current, ok := live.Permissions[strings.ToLower(team)]
+		switch {
+		case !ok:
+			p.add(ActionAdd, resource, fmt.Sprintf("give %s permission on repository %s to team %s", permission, name, team), func(a *applier) error {
+				return a.hubClient.AddRepositoryPermission(name, a.teamIDs[strings.ToLower(team)], permission)
+			})
+		case current.Permission != permission:
+			p.add(ActionChange, resource, fmt.Sprintf("change the permission of team %s on repository %s from %s to %s", team, name, current.Permission, permission), func(a *applier) error {
+				return a.hubClient.UpdateRepositoryPermission(name, current.TeamID, permission)
+			})
+		}
+	}
+	var revoked []hub.TeamPermission
+	for team, permission := range live.Permissions {
+		if _, ok := lookup(repository.Permissions, team); !ok {
+			revoked = append(revoked, permission)
+		}
+	}
+	sort.Slice(revoked, func(i, j int) bool { return revoked[i].Team < revoked[j].Team })
+	for _, permission := range revoked {
+		permission := permission
+		p.add(ActionRemove, resource, fmt.Sprintf("revoke the %s permission of team %s on repository %s", permission.Permission, permission.Team, name), func(a *applier) error {
+			return a.hubClient.RemoveRepositoryPermission(name, permission.TeamID)
+		})
+	}
+	return nil
+}
+
+// lookup finds a value by case insensitive key
+func lookup(m map[string]string, key string) (string, bool) {
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func isInvited(invites []hub.Invite, invitee, team string) bool {
+	for _, invite := range invites {
+		if strings.EqualFold(invite.Invitee, invitee) && strings.EqualFold(invite.Team, team) {
+			return true
+		}
+	}
+	return false
+}
+
+type applier struct {
+	hubClient    *hub.Client
+	organization string
+	// teamIDs by lower case team name, completed with the created teams
+	teamIDs map[string]int
+}
+
+func (a *applier) invite(team, invitee string) error {
+	results, err := a.hubClient.InviteMembers(a.organization, team, hub.RoleMember, []string{invitee})
+	if err != nil {
+		return err
+	}
+	if len(results) != 1 || results[0].Status != hub.InviteStatusInvited {
+		status := "no invite sent"
+		if len(results) == 1 {
+			status = strings.ReplaceAll(results[0].Status, "_", " ")
+		}
+		return errors.New(status)
+	}
+	return nil
+}
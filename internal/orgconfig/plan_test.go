@@ -0,0 +1,193 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package orgconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+func liveState() *State {
+	return &State{
+		Teams: map[string]hub.Team{
+			"owners":     {ID: 1, Name: "owners", Members: []hub.Member{{Username: "admin"}}},
+			"developers": {ID: 2, Name: "developers", Description: "Developers", Members: []hub.Member{{Username: "alice"}, {Username: "carol"}}},
+			"legacy":     {ID: 3, Name: "legacy"},
+		},
+		Members: map[string]bool{"admin": true, "alice": true, "bob": true, "carol": true},
+		Invites: []hub.Invite{{Invitee: "erin", Team: "qa"}},
+		Repositories: map[string]RepositoryState{
+			"myorg/app": {
+				Repository: hub.Repository{Name: "myorg/app", Description: "The app", IsPrivate: false},
+				Permissions: map[string]hub.TeamPermission{
+					"developers": {TeamID: 2, Team: "developers", Permission: hub.PermissionRead},
+					"legacy":     {TeamID: 3, Team: "legacy", Permission: hub.PermissionAdmin},
+				},
+			},
+		},
+	}
+}
+
+func loadConfig(t *testing.T, config string) *Config {
+	c, err := Load(strings.NewReader(config))
+	assert.NilError(t, err)
+	return c
+}
+
+const orgConfig = `organization: myorg
+teams:
+  - name: developers
+    description: Build the images
+    members: [alice, Bob, dave]
+  - name: qa
+    members: [erin]
+repositories:
+  - name: app
+    description: The app
+    private: true
+    permissions:
+      developers: write
+      qa: read
+`
+
+func descriptions(plan *Plan) []string {
+	var result []string
+	for _, c := range plan.Changes {
+		result = append(result, fmt.Sprintf("%s %s", c.Action, c.Description))
+	}
+	return result
+}
+
+func TestNewPlan(t *testing.T) {
+	plan, err := NewPlan(loadConfig(t, orgConfig), liveState(), false)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, descriptions(plan), []string{
+		`change set the description of team developers to "Build the images"`,
+		"add add Bob to team developers",
+		"add invite dave to team developers",
+		"remove remove carol from team developers",
+		"add create team qa",
+		"change make repository myorg/app private",
+		"change change the permission of team developers on repository myorg/app from read to write",
+		"add give read permission on repository myorg/app to team qa",
+		"remove revoke the admin permission of team legacy on repository myorg/app",
+	})
+	assert.Equal(t, plan.Count(ActionAdd), 4)
+	assert.Equal(t, plan.Count(ActionChange), 3)
+	assert.Equal(t, plan.Count(ActionRemove), 2)
+}
+
+func TestNewPlanPrunesTheUndeclaredTeamsButOwners(t *testing.T) {
+	plan, err := NewPlan(loadConfig(t, "organization: myorg\nteams:\n  - name: developers\n"), liveState(), true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, descriptions(plan), []string{"remove delete team legacy"})
+}
+
+func TestNewPlanIsEmptyWhenUpToDate(t *testing.T) {
+	plan, err := NewPlan(loadConfig(t, `organization: myorg
+teams:
+  - name: developers
+    members: [alice, carol]
+  - name: legacy
+repositories:
+  - name: app
+    private: false
+`), liveState(), false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(plan.Changes), 0)
+	data, err := json.Marshal(plan)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"organization":"myorg","changes":[]}`)
+}
+
+func TestNewPlanConvergesOnceTheInviteIsAccepted(t *testing.T) {
+	config := loadConfig(t, "organization: myorg\nteams:\n  - name: developers\n    members: [alice, carol, erin]\n")
+	state := liveState()
+	state.Invites = []hub.Invite{{Invitee: "erin", Team: "developers"}}
+	plan, err := NewPlan(config, state, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(plan.Changes), 0, "erin joins the team once the invite is accepted")
+
+	// The accepted invite is gone and erin is a member of the team
+	state.Invites = nil
+	state.Members["erin"] = true
+	developers := state.Teams["developers"]
+	developers.Members = append(developers.Members, hub.Member{Username: "erin"})
+	state.Teams["developers"] = developers
+	plan, err = NewPlan(config, state, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(plan.Changes), 0)
+}
+
+func TestNewPlanErrors(t *testing.T) {
+	_, err := NewPlan(loadConfig(t, "organization: myorg\nrepositories:\n  - name: unknown\n"), liveState(), false)
+	assert.Error(t, err, "repository myorg/unknown doesn't exist")
+
+	_, err = NewPlan(loadConfig(t, "organization: myorg\nrepositories:\n  - name: app\n    permissions:\n      ops: read\n"), liveState(), false)
+	assert.Error(t, err, "repository myorg/app: team ops doesn't exist")
+}
+
+func TestApplyUsesTheIDOfTheCreatedTeams(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/orgs/myorg/groups/":
+			fmt.Fprint(w, `{"id": 42, "name": "qa"}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/repositories/myorg/app/groups/3/":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("DOCKER_HUB_API_URL", server.URL)
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+	hubClient, err := hub.NewClient()
+	assert.NilError(t, err)
+
+	plan, err := NewPlan(loadConfig(t, `organization: myorg
+teams:
+  - name: qa
+repositories:
+  - name: app
+    permissions:
+      developers: read
+      qa: write
+`), liveState(), false)
+	assert.NilError(t, err)
+	var applied []string
+	err = plan.Apply(hubClient, func(c Change) { applied = append(applied, c.Description) })
+	assert.Error(t, err, "failed to revoke the admin permission of team legacy on repository myorg/app: operation not permitted (2 of 3 changes applied)")
+	assert.DeepEqual(t, applied, []string{"create team qa", "give write permission on repository myorg/app to team qa"})
+	assert.DeepEqual(t, calls, []string{
+		`POST /v2/orgs/myorg/groups/ {"name":"qa","description":""}`,
+		`POST /v2/repositories/myorg/app/groups/ {"group_id":42,"permission":"write"}`,
+		"DELETE /v2/repositories/myorg/app/groups/3/ ",
+	})
+}
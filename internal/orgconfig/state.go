@@ -0,0 +1,97 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package orgconfig
+
+import (
+	"strings"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// State is the live state of an organization, the names being lower case
+type State struct {
+	// Teams by name
+	Teams map[string]hub.Team
+	// Members are the usernames of the members of the organization
+	Members map[string]bool
+	// Invites are the pending invites of the organization
+	Invites []hub.Invite
+	// Repositories by qualified name
+	Repositories map[string]RepositoryState
+}
+
+// RepositoryState is the live state of a repository
+type RepositoryState struct {
+	hub.Repository
+	// Permissions by team name, only fetched for the repositories managing their permissions
+	Permissions map[string]hub.TeamPermission
+}
+
+// Fetch returns the live state of the organization described by the configuration
+func Fetch(hubClient *hub.Client, config *Config) (*State, error) {
+	if err := hubClient.Update(hub.WithAllElements()); err != nil {
+		return nil, err
+	}
+	teams, err := hubClient.GetTeams(config.Organization)
+	if err != nil {
+		return nil, err
+	}
+	members, err := hubClient.GetMembers(config.Organization)
+	if err != nil {
+		return nil, err
+	}
+	invites, err := hubClient.GetInvites(config.Organization)
+	if err != nil {
+		return nil, err
+	}
+	repositories, _, err := hubClient.GetRepositories(config.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{
+		Teams:        map[string]hub.Team{},
+		Members:      map[string]bool{},
+		Invites:      invites,
+		Repositories: map[string]RepositoryState{},
+	}
+	for _, team := range teams {
+		state.Teams[strings.ToLower(team.Name)] = team
+	}
+	for _, member := range members {
+		state.Members[strings.ToLower(member.Username)] = true
+	}
+	for _, repository := range repositories {
+		state.Repositories[strings.ToLower(repository.Name)] = RepositoryState{Repository: repository}
+	}
+	for _, repository := range config.Repositories {
+		live, ok := state.Repositories[repository.Name]
+		if !ok || repository.Permissions == nil {
+			continue
+		}
+		permissions, err := hubClient.GetRepositoryPermissions(repository.Name)
+		if err != nil {
+			return nil, err
+		}
+		live.Permissions = map[string]hub.TeamPermission{}
+		for _, permission := range permissions {
+			live.Permissions[strings.ToLower(permission.Team)] = permission
+		}
+		state.Repositories[repository.Name] = live
+	}
+	return state, nil
+}
@@ -0,0 +1,56 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package prompt asks the user for input, giving up when the command is canceled
+package prompt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/hub-tool/internal/ansi"
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+// Ask prints the question and returns the answer read from in, trimmed and lower cased.
+// It returns errdef.ErrCanceled if the context is done before the user answers.
+func Ask(ctx context.Context, in io.Reader, out io.Writer, question string) (string, error) {
+	fmt.Fprint(out, question)
+	userIn := make(chan string, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		input, _ := reader.ReadString('\n')
+		userIn <- strings.ToLower(strings.TrimSpace(input))
+	}()
+	select {
+	case <-ctx.Done():
+		return "", errdef.ErrCanceled
+	case input := <-userIn:
+		return input, nil
+	}
+}
+
+// Confirm asks a yes or no question, no being the default answer
+func Confirm(ctx context.Context, in io.Reader, out io.Writer, question string) (bool, error) {
+	input, err := Ask(ctx, in, out, ansi.Info(question+" [y/N] "))
+	if err != nil {
+		return false, err
+	}
+	return input == "y", nil
+}
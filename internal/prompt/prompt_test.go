@@ -0,0 +1,59 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/internal/errdef"
+)
+
+func TestConfirm(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{"y\n", true},
+		{" Y \n", true},
+		{"n\n", false},
+		{"yes please\n", false},
+		{"", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			confirmed, err := Confirm(context.Background(), strings.NewReader(tc.input), out, "Are you sure?")
+			assert.NilError(t, err)
+			assert.Equal(t, confirmed, tc.expected)
+			assert.Equal(t, out.String(), "Are you sure? [y/N] ")
+		})
+	}
+}
+
+func TestAskIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	in, _ := io.Pipe()
+	_, err := Ask(ctx, in, io.Discard, "Name: ")
+	assert.Equal(t, err, errdef.ErrCanceled)
+}
@@ -0,0 +1,541 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package schema defines the documented JSON representation of the Hub
+// resources printed by the structured output formats. Fields are only added
+// within a version, renaming or removing one requires a new version of the
+// schema. The JSON Schema of each resource is in the directory of its version.
+package schema
+
+import (
+	"time"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+// Kinds of resources
+const (
+	KindRepository    = "repository"
+	KindTag           = "tag"
+	KindToken         = "token"
+	KindOrganization  = "organization"
+	KindMember        = "member"
+	KindTeam          = "team"
+	KindOrgToken      = "org_token"
+	KindInvite        = "invite"
+	KindAuditLog      = "audit_log"
+	KindDiff          = "diff"
+	KindPackage       = "package"
+	KindLicense       = "license"
+	KindProvenance    = "provenance"
+	KindLatestVersion = "latest_version"
+	KindAccount       = "account"
+	KindPlan          = "plan"
+)
+
+// Repository is the JSON representation of a repository
+type Repository struct {
+	Name        string     `json:"name"`
+	Namespace   string     `json:"namespace"`
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	LastUpdated *time.Time `json:"last_updated"`
+	PullCount   int        `json:"pull_count"`
+	StarCount   int        `json:"star_count"`
+	IsPrivate   bool       `json:"is_private"`
+}
+
+// Tag is the JSON representation of a tag
+type Tag struct {
+	Name                string     `json:"name"`
+	Digest              string     `json:"digest"`
+	FullSize            int        `json:"full_size"`
+	Status              string     `json:"status"`
+	LastUpdated         *time.Time `json:"last_updated"`
+	LastUpdaterUsername string     `json:"last_updater_username"`
+	LastPulled          *time.Time `json:"last_pulled"`
+	LastPushed          *time.Time `json:"last_pushed"`
+	Images              []Image    `json:"images"`
+}
+
+// Image is the JSON representation of the image of a tag for a platform
+type Image struct {
+	Digest       string     `json:"digest"`
+	OS           string     `json:"os"`
+	Architecture string     `json:"architecture"`
+	Variant      string     `json:"variant"`
+	Size         int        `json:"size"`
+	Status       string     `json:"status"`
+	LastPulled   *time.Time `json:"last_pulled"`
+	LastPushed   *time.Time `json:"last_pushed"`
+}
+
+// Token is the JSON representation of a personal access token
+type Token struct {
+	UUID        string     `json:"uuid"`
+	Description string     `json:"description"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   *time.Time `json:"created_at"`
+	LastUsed    *time.Time `json:"last_used"`
+	GeneratedBy string     `json:"generated_by"`
+	ClientID    string     `json:"client_id"`
+	CreatorIP   string     `json:"creator_ip"`
+	CreatorUA   string     `json:"creator_ua"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	// Token is only set at creation
+	Token string `json:"token,omitempty"`
+}
+
+// OrgToken is the JSON representation of an organization access token
+type OrgToken struct {
+	ID          string             `json:"id"`
+	Label       string             `json:"label"`
+	Description string             `json:"description"`
+	CreatedBy   string             `json:"created_by"`
+	IsActive    bool               `json:"is_active"`
+	CreatedAt   *time.Time         `json:"created_at"`
+	LastUsed    *time.Time         `json:"last_used"`
+	ExpiresAt   *time.Time         `json:"expires_at"`
+	Resources   []OrgTokenResource `json:"resources"`
+	// Token is only set at creation
+	Token string `json:"token,omitempty"`
+}
+
+// OrgTokenResource is the JSON representation of a resource an organization access token can access
+type OrgTokenResource struct {
+	Type   string   `json:"type"`
+	Path   string   `json:"path"`
+	Scopes []string `json:"scopes"`
+}
+
+// Organization is the JSON representation of an organization
+type Organization struct {
+	Namespace string   `json:"namespace"`
+	FullName  string   `json:"full_name"`
+	Role      string   `json:"role"`
+	Teams     []Team   `json:"teams"`
+	Members   []Member `json:"members"`
+}
+
+// Member is the JSON representation of a member of an organization
+type Member struct {
+	Username string `json:"username"`
+	FullName string `json:"full_name"`
+}
+
+// Team is the JSON representation of a team of an organization
+type Team struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Members     []Member `json:"members"`
+}
+
+// Invite is the JSON representation of a pending invite to join an organization
+type Invite struct {
+	ID           string     `json:"id"`
+	Inviter      string     `json:"inviter"`
+	Invitee      string     `json:"invitee"`
+	Organization string     `json:"organization"`
+	Team         string     `json:"team"`
+	Role         string     `json:"role"`
+	CreatedAt    *time.Time `json:"created_at"`
+}
+
+// AuditLog is the JSON representation of an event of the audit log of an organization
+type AuditLog struct {
+	Account     string            `json:"account"`
+	Action      string            `json:"action"`
+	Name        string            `json:"name"`
+	Actor       string            `json:"actor"`
+	Description string            `json:"description"`
+	Data        map[string]string `json:"data"`
+	Timestamp   *time.Time        `json:"timestamp"`
+}
+
+// Diff is the JSON representation of the differences between two tags
+type Diff struct {
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Platforms *PlatformsDiff `json:"platforms"`
+	Image     *ImageDiff     `json:"image"`
+}
+
+// PlatformsDiff is the JSON representation of the platforms added, removed or changed between two indexes
+type PlatformsDiff struct {
+	Added   []string         `json:"added"`
+	Removed []string         `json:"removed"`
+	Changed []PlatformChange `json:"changed"`
+}
+
+// PlatformChange is the JSON representation of a platform whose manifest changed
+type PlatformChange struct {
+	Platform string `json:"platform"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// ImageDiff is the JSON representation of the differences between two images
+type ImageDiff struct {
+	Platform string          `json:"platform"`
+	Config   []ConfigChange  `json:"config"`
+	Layers   LayersDiff      `json:"layers"`
+	History  []HistoryChange `json:"history"`
+}
+
+// ConfigChange is the JSON representation of a config field added, removed or modified
+type ConfigChange struct {
+	Kind  string `json:"kind"`
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// LayersDiff is the JSON representation of the layers of two images compared by digest
+type LayersDiff struct {
+	Shared    []Layer `json:"shared"`
+	Added     []Layer `json:"added"`
+	Removed   []Layer `json:"removed"`
+	FromSize  int64   `json:"from_size"`
+	ToSize    int64   `json:"to_size"`
+	SizeDelta int64   `json:"size_delta"`
+}
+
+// Layer is the JSON representation of a layer of an image
+type Layer struct {
+	MediaType string `json:"media_type"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// HistoryChange is the JSON representation of a history command only present in one of the images
+type HistoryChange struct {
+	Op      string `json:"op"`
+	Command string `json:"command"`
+}
+
+// Package is the JSON representation of a software package listed in an SBOM
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	License string `json:"license"`
+}
+
+// License is the JSON representation of the number of packages released under a license
+type License struct {
+	License  string `json:"license"`
+	Packages int    `json:"packages"`
+}
+
+// Provenance is the JSON representation of how an image was built
+type Provenance struct {
+	Name          string            `json:"name"`
+	Platform      string            `json:"platform"`
+	PredicateType string            `json:"predicate_type"`
+	BuilderID     string            `json:"builder_id"`
+	BuildType     string            `json:"build_type"`
+	Source        ProvenanceSource  `json:"source"`
+	BuildArgs     map[string]string `json:"build_args"`
+	Materials     []Material        `json:"materials"`
+	StartedOn     *time.Time        `json:"started_on"`
+	FinishedOn    *time.Time        `json:"finished_on"`
+}
+
+// ProvenanceSource is the JSON representation of the repository and commit an image was built from
+type ProvenanceSource struct {
+	Repository string `json:"repository"`
+	Commit     string `json:"commit"`
+	EntryPoint string `json:"entry_point"`
+}
+
+// Material is the JSON representation of an artifact used by a build
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+// LatestVersion is the JSON representation of the newest tag of a variant
+type LatestVersion struct {
+	Variant string `json:"variant"`
+	Version string `json:"version"`
+	Tag     Tag    `json:"tag"`
+}
+
+// Account is the JSON representation of a user or an organization with its plan
+type Account struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	FullName    string             `json:"full_name"`
+	Company     string             `json:"company"`
+	Location    string             `json:"location"`
+	Joined      *time.Time         `json:"joined"`
+	Plan        string             `json:"plan"`
+	Limits      AccountLimits      `json:"limits"`
+	Consumption AccountConsumption `json:"consumption"`
+}
+
+// AccountLimits is the JSON representation of the limits of a plan, 9999 being unlimited
+type AccountLimits struct {
+	Seats               int `json:"seats"`
+	PrivateRepositories int `json:"private_repositories"`
+	Teams               int `json:"teams"`
+	Collaborators       int `json:"collaborators"`
+	ParallelBuilds      int `json:"parallel_builds"`
+}
+
+// AccountConsumption is the JSON representation of the resources used by an account
+type AccountConsumption struct {
+	Seats               int `json:"seats"`
+	PrivateRepositories int `json:"private_repositories"`
+	Teams               int `json:"teams"`
+}
+
+// Plan is the JSON representation of the changes reconciling an organization with its configuration file
+type Plan struct {
+	Organization string       `json:"organization"`
+	Changes      []PlanChange `json:"changes"`
+}
+
+// PlanChange is the JSON representation of a change of a plan
+type PlanChange struct {
+	Action      string `json:"action"`
+	Resource    string `json:"resource"`
+	Description string `json:"description"`
+}
+
+// NewRepositories converts repositories to their JSON representation
+func NewRepositories(repositories []hub.Repository) []Repository {
+	result := make([]Repository, 0, len(repositories))
+	for _, r := range repositories {
+		result = append(result, Repository{
+			Name:        r.Name,
+			Namespace:   r.Namespace,
+			Type:        string(r.Type),
+			Description: r.Description,
+			LastUpdated: optionalTime(r.LastUpdated),
+			PullCount:   r.PullCount,
+			StarCount:   r.StarCount,
+			IsPrivate:   r.IsPrivate,
+		})
+	}
+	return result
+}
+
+// NewTag converts a tag to its JSON representation
+func NewTag(t hub.Tag) Tag {
+	images := make([]Image, 0, len(t.Images))
+	for _, i := range t.Images {
+		images = append(images, Image{
+			Digest:       i.Digest,
+			OS:           i.Os,
+			Architecture: i.Architecture,
+			Variant:      i.Variant,
+			Size:         i.Size,
+			Status:       i.Status,
+			LastPulled:   optionalTime(i.LastPulled),
+			LastPushed:   optionalTime(i.LastPushed),
+		})
+	}
+	return Tag{
+		Name:                t.Name,
+		Digest:              t.Digest,
+		FullSize:            t.FullSize,
+		Status:              t.Status,
+		LastUpdated:         optionalTime(t.LastUpdated),
+		LastUpdaterUsername: t.LastUpdaterUserName,
+		LastPulled:          optionalTime(t.LastPulled),
+		LastPushed:          optionalTime(t.LastPushed),
+		Images:              images,
+	}
+}
+
+// NewTags converts tags to their JSON representation
+func NewTags(tags []hub.Tag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, NewTag(t))
+	}
+	return result
+}
+
+// NewToken converts a token to its JSON representation
+func NewToken(token hub.Token) Token {
+	return Token{
+		UUID:        token.UUID.String(),
+		Description: token.Description,
+		IsActive:    token.IsActive,
+		CreatedAt:   optionalTime(token.CreatedAt),
+		LastUsed:    optionalTime(token.LastUsed),
+		GeneratedBy: token.GeneratedBy,
+		ClientID:    token.ClientID,
+		CreatorIP:   token.CreatorIP,
+		CreatorUA:   token.CreatorUA,
+		Scopes:      append([]string{}, token.Scopes...),
+		ExpiresAt:   optionalTime(token.ExpiresAt),
+		Token:       token.Token,
+	}
+}
+
+// NewTokens converts tokens to their JSON representation
+func NewTokens(tokens []hub.Token) []Token {
+	result := make([]Token, 0, len(tokens))
+	for _, t := range tokens {
+		result = append(result, NewToken(t))
+	}
+	return result
+}
+
+// NewOrgToken converts an organization access token to its JSON representation
+func NewOrgToken(token hub.OrgToken) OrgToken {
+	resources := make([]OrgTokenResource, 0, len(token.Resources))
+	for _, r := range token.Resources {
+		resources = append(resources, OrgTokenResource{
+			Type:   r.Type,
+			Path:   r.Path,
+			Scopes: append([]string{}, r.Scopes...),
+		})
+	}
+	return OrgToken{
+		ID:          token.ID.String(),
+		Label:       token.Label,
+		Description: token.Description,
+		CreatedBy:   token.CreatedBy,
+		IsActive:    token.IsActive,
+		CreatedAt:   optionalTime(token.CreatedAt),
+		LastUsed:    optionalTime(token.LastUsed),
+		ExpiresAt:   optionalTime(token.ExpiresAt),
+		Resources:   resources,
+		Token:       token.Token,
+	}
+}
+
+// NewOrgTokens converts organization access tokens to their JSON representation
+func NewOrgTokens(tokens []hub.OrgToken) []OrgToken {
+	result := make([]OrgToken, 0, len(tokens))
+	for _, t := range tokens {
+		result = append(result, NewOrgToken(t))
+	}
+	return result
+}
+
+// NewOrganizations converts organizations to their JSON representation
+func NewOrganizations(organizations []hub.Organization) []Organization {
+	result := make([]Organization, 0, len(organizations))
+	for _, o := range organizations {
+		result = append(result, Organization{
+			Namespace: o.Namespace,
+			FullName:  o.FullName,
+			Role:      o.Role,
+			Teams:     NewTeams(o.Teams),
+			Members:   NewMembers(o.Members),
+		})
+	}
+	return result
+}
+
+// NewMembers converts members to their JSON representation
+func NewMembers(members []hub.Member) []Member {
+	result := make([]Member, 0, len(members))
+	for _, m := range members {
+		result = append(result, Member{Username: m.Username, FullName: m.FullName})
+	}
+	return result
+}
+
+// NewTeams converts teams to their JSON representation
+func NewTeams(teams []hub.Team) []Team {
+	result := make([]Team, 0, len(teams))
+	for _, t := range teams {
+		result = append(result, Team{
+			Name:        t.Name,
+			Description: t.Description,
+			Members:     NewMembers(t.Members),
+		})
+	}
+	return result
+}
+
+// NewInvites converts invites to their JSON representation
+func NewInvites(invites []hub.Invite) []Invite {
+	result := make([]Invite, 0, len(invites))
+	for _, i := range invites {
+		result = append(result, Invite{
+			ID:           i.ID,
+			Inviter:      i.Inviter,
+			Invitee:      i.Invitee,
+			Organization: i.Organization,
+			Team:         i.Team,
+			Role:         i.Role,
+			CreatedAt:    optionalTime(i.CreatedAt),
+		})
+	}
+	return result
+}
+
+// NewAuditLogs converts audit log events to their JSON representation
+func NewAuditLogs(logs []hub.AuditLog) []AuditLog {
+	result := make([]AuditLog, 0, len(logs))
+	for _, l := range logs {
+		data := map[string]string{}
+		for k, v := range l.Data {
+			data[k] = v
+		}
+		result = append(result, AuditLog{
+			Account:     l.Account,
+			Action:      l.Action,
+			Name:        l.Name,
+			Actor:       l.Actor,
+			Description: l.Description,
+			Data:        data,
+			Timestamp:   optionalTime(l.Timestamp),
+		})
+	}
+	return result
+}
+
+// NewAccount converts an account, its plan and its consumption to their JSON representation
+func NewAccount(account hub.Account, plan hub.Plan, consumption hub.Consumption) Account {
+	return Account{
+		ID:       account.ID,
+		Name:     account.Name,
+		FullName: account.FullName,
+		Company:  account.Company,
+		Location: account.Location,
+		Joined:   optionalTime(account.Joined),
+		Plan:     plan.Name,
+		Limits: AccountLimits{
+			Seats:               plan.Limits.Seats,
+			PrivateRepositories: plan.Limits.PrivateRepos,
+			Teams:               plan.Limits.Teams,
+			Collaborators:       plan.Limits.Collaborators,
+			ParallelBuilds:      plan.Limits.ParallelBuilds,
+		},
+		Consumption: AccountConsumption{
+			Seats:               consumption.Seats,
+			PrivateRepositories: consumption.PrivateRepositories,
+			Teams:               consumption.Teams,
+		},
+	}
+}
+
+// optionalTime returns nil for the zero time, printed as null
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
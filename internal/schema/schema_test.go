@@ -0,0 +1,235 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/hub-tool/pkg/hub"
+)
+
+type jsonSchema struct {
+	Properties           map[string]jsonProperty `json:"properties"`
+	Required             []string                `json:"required"`
+	AdditionalProperties *bool                   `json:"additionalProperties"`
+	Defs                 map[string]jsonSchema   `json:"$defs"`
+}
+
+type jsonProperty struct {
+	Type  interface{}    `json:"type"`
+	Ref   string         `json:"$ref"`
+	Enum  []string       `json:"enum"`
+	Items *jsonProperty  `json:"items"`
+	OneOf []jsonProperty `json:"oneOf"`
+}
+
+var kinds = []struct {
+	kind  string
+	value interface{}
+}{
+	{KindRepository, Repository{}},
+	{KindTag, Tag{}},
+	{KindToken, Token{}},
+	{KindOrganization, Organization{}},
+	{KindMember, Member{}},
+	{KindTeam, Team{}},
+	{KindOrgToken, OrgToken{}},
+	{KindInvite, Invite{}},
+	{KindAuditLog, AuditLog{}},
+	{KindDiff, Diff{}},
+	{KindPackage, Package{}},
+	{KindLicense, License{}},
+	{KindProvenance, Provenance{}},
+	{KindLatestVersion, LatestVersion{}},
+	{KindAccount, Account{}},
+	{KindPlan, Plan{}},
+}
+
+func readSchema(t *testing.T, name string) jsonSchema {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("v1", name+".schema.json"))
+	assert.NilError(t, err)
+	var s jsonSchema
+	assert.NilError(t, json.Unmarshal(data, &s))
+	return s
+}
+
+func jsonFields(v interface{}) []string {
+	var fields []string
+	typ := reflect.TypeOf(v)
+	for i := 0; i < typ.NumField(); i++ {
+		fields = append(fields, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func properties(s jsonSchema) []string {
+	var names []string
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schemaTypes returns the JSON types of a property, a reference being an object
+func schemaTypes(p jsonProperty) []string {
+	var types []string
+	for _, alternative := range p.OneOf {
+		types = append(types, schemaTypes(alternative)...)
+	}
+	switch typ := p.Type.(type) {
+	case string:
+		types = []string{typ}
+	case []interface{}:
+		for _, t := range typ {
+			types = append(types, t.(string))
+		}
+	}
+	if p.Ref != "" {
+		types = append(types, "object")
+	}
+	sort.Strings(types)
+	return types
+}
+
+// goTypes returns the JSON types a Go type is marshaled to
+func goTypes(typ reflect.Type) []string {
+	var types []string
+	switch {
+	case typ.Kind() == reflect.Pointer:
+		types = append(goTypes(typ.Elem()), "null")
+	case typ == reflect.TypeOf(time.Time{}), typ.Kind() == reflect.String:
+		types = []string{"string"}
+	case typ.Kind() == reflect.Bool:
+		types = []string{"boolean"}
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Uint64:
+		types = []string{"integer"}
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		types = []string{"number"}
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		types = []string{"array"}
+	case typ.Kind() == reflect.Struct || typ.Kind() == reflect.Map:
+		types = []string{"object"}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// assertSchemaMatches checks that the schema describes all the fields of the value with
+// their types, requires them all and rejects any other
+func assertSchemaMatches(t *testing.T, s jsonSchema, value interface{}) {
+	t.Helper()
+	assert.DeepEqual(t, properties(s), jsonFields(value))
+	assert.Assert(t, s.AdditionalProperties != nil && !*s.AdditionalProperties, "additional properties should be rejected")
+
+	var required []string
+	typ := reflect.TypeOf(value)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if options != "omitempty" {
+			required = append(required, name)
+		}
+		p := s.Properties[name]
+		assert.DeepEqual(t, schemaTypes(p), goTypes(field.Type))
+		if p.Items != nil {
+			assert.DeepEqual(t, schemaTypes(*p.Items), goTypes(field.Type.Elem()))
+		}
+	}
+	sort.Strings(required)
+	sort.Strings(s.Required)
+	assert.DeepEqual(t, s.Required, required)
+}
+
+func TestSchemaFilesMatchTypes(t *testing.T) {
+	for _, k := range kinds {
+		t.Run(k.kind, func(t *testing.T) {
+			assertSchemaMatches(t, readSchema(t, k.kind), k.value)
+		})
+	}
+	testCases := []struct {
+		kind  string
+		def   string
+		value interface{}
+	}{
+		{KindTag, "image", Image{}},
+		{KindOrgToken, "resource", OrgTokenResource{}},
+		{KindDiff, "platforms", PlatformsDiff{}},
+		{KindDiff, "platform_change", PlatformChange{}},
+		{KindDiff, "image", ImageDiff{}},
+		{KindDiff, "config_change", ConfigChange{}},
+		{KindDiff, "layers", LayersDiff{}},
+		{KindDiff, "layer", Layer{}},
+		{KindDiff, "history_change", HistoryChange{}},
+		{KindProvenance, "source", ProvenanceSource{}},
+		{KindProvenance, "material", Material{}},
+		{KindAccount, "limits", AccountLimits{}},
+		{KindAccount, "consumption", AccountConsumption{}},
+		{KindPlan, "change", PlanChange{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.kind+"/"+tc.def, func(t *testing.T) {
+			assertSchemaMatches(t, readSchema(t, tc.kind).Defs[tc.def], tc.value)
+		})
+	}
+}
+
+func TestEnvelopesListEveryKind(t *testing.T) {
+	var expected []string
+	for _, k := range kinds {
+		expected = append(expected, k.kind)
+	}
+	for _, envelope := range []string{"list", "resource"} {
+		assert.DeepEqual(t, readSchema(t, envelope).Properties["kind"].Enum, expected)
+	}
+}
+
+func TestZeroTimesAreNull(t *testing.T) {
+	created := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	token := NewToken(hub.Token{
+		UUID:        uuid.MustParse("2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a"),
+		Description: "ci",
+		CreatedAt:   created,
+		IsActive:    true,
+	})
+	data, err := json.Marshal(token)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"uuid":"2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a","description":"ci","is_active":true,`+
+		`"created_at":"2021-01-02T03:04:05Z","last_used":null,"generated_by":"","client_id":"","creator_ip":"","creator_ua":"","scopes":[],"expires_at":null}`)
+}
+
+func TestEmptyListsAreArrays(t *testing.T) {
+	data, err := json.Marshal(NewTags([]hub.Tag{{Name: "user/repo:latest"}}))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(data), `"images":[]`))
+
+	data, err = json.Marshal(NewRepositories(nil))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "[]")
+}
@@ -0,0 +1,162 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var rangeRegexp = regexp.MustCompile(`^(\^|~|>=|<=|>|<|=)?\s*[vV]?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?(?:-(\S+))?$`)
+
+// Constraint is a set of version ranges, like "^1.4", "~1.4.2", "1.4.x" or ">=1.2 <2.0 || 3.x".
+// Comparators separated by spaces or commas must all match, "||" separates alternatives.
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version Version
+}
+
+// ParseConstraint parses a version constraint
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: s}
+	for _, alternative := range strings.Split(s, "||") {
+		var set []comparator
+		for _, r := range strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' }) {
+			comparators, err := parseRange(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid constraint %q: %s", s, err)
+			}
+			set = append(set, comparators...)
+		}
+		if len(set) == 0 {
+			return nil, fmt.Errorf("invalid constraint %q: empty range", s)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Check returns true if the version satisfies the constraint
+func (c *Constraint) Check(v *Version) bool {
+	for _, set := range c.sets {
+		ok := true
+		for _, cmp := range set {
+			if !cmp.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparator) check(v *Version) bool {
+	r := v.Compare(&c.version)
+	switch c.op {
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	default:
+		return r == 0
+	}
+}
+
+// parseRange converts a single range into comparators, partial versions being x-ranges
+func parseRange(s string) ([]comparator, error) {
+	m := rangeRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%q is not a valid range", s)
+	}
+	op := m[1]
+	var numbers []int
+	for _, part := range m[2:5] {
+		if part == "" || strings.ContainsAny(part, "xX*") {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	lower := Version{Prerelease: m[5]}
+	for i, dst := range []*int{&lower.Major, &lower.Minor, &lower.Patch} {
+		if i < len(numbers) {
+			*dst = numbers[i]
+		}
+	}
+	atLeast := comparator{">=", lower}
+
+	switch op {
+	case "^":
+		// Allow changes that do not modify the left-most non-zero number
+		for i, n := range numbers {
+			if n != 0 || i == len(numbers)-1 {
+				return []comparator{atLeast, {"<", bump(numbers, i)}}, nil
+			}
+		}
+		return []comparator{atLeast}, nil
+	case "~":
+		// Allow patch level changes, or minor ones if only the major is given
+		if len(numbers) == 0 {
+			return []comparator{atLeast}, nil
+		}
+		i := 1
+		if len(numbers) < 2 {
+			i = 0
+		}
+		return []comparator{atLeast, {"<", bump(numbers, i)}}, nil
+	case "", "=":
+		if len(numbers) == 0 {
+			return []comparator{atLeast}, nil
+		}
+		if len(numbers) == 3 {
+			return []comparator{{"=", lower}}, nil
+		}
+		return []comparator{atLeast, {"<", bump(numbers, len(numbers)-1)}}, nil
+	default:
+		return []comparator{{op, lower}}, nil
+	}
+}
+
+// bump returns the version incrementing the number at index i and resetting the following ones
+func bump(numbers []int, i int) Version {
+	n := make([]int, 3)
+	copy(n, numbers[:i+1])
+	n[i]++
+	// The lowest prerelease keeps prereleases of the next version out of the range
+	return Version{Major: n[0], Minor: n[1], Patch: n[2], Prerelease: "0"}
+}
@@ -0,0 +1,138 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	versionRegexp    = regexp.MustCompile(`^[vV]?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-(.+))?$`)
+	prereleaseRegexp = regexp.MustCompile(`^(?i)(alpha|beta|rc|pre|preview|dev)[.]?\d*$`)
+	numberRegexp     = regexp.MustCompile(`\d+|\D+`)
+)
+
+// Version is a semantic version parsed from an image tag, like "v1.4.2",
+// "1.4-alpine" or "2.0.0-rc1-slim". The suffix after the version is split in
+// a prerelease (alpha, beta, rc...) and a variant (alpine, slim...).
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Variant    string
+	Original   string
+}
+
+// Parse parses a tag as a semantic version, tolerating a "v" prefix, missing
+// minor or patch numbers and variant suffixes
+func Parse(tag string) (*Version, error) {
+	m := versionRegexp.FindStringSubmatch(tag)
+	if m == nil {
+		return nil, fmt.Errorf("%q is not a semantic version", tag)
+	}
+	v := &Version{Original: tag}
+	for i, dst := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a semantic version: %s", tag, err)
+		}
+		*dst = n
+	}
+	if m[4] != "" {
+		parts := strings.SplitN(m[4], "-", 2)
+		if prereleaseRegexp.MatchString(parts[0]) {
+			v.Prerelease = parts[0]
+			if len(parts) == 2 {
+				v.Variant = parts[1]
+			}
+		} else {
+			v.Variant = m[4]
+		}
+	}
+	return v, nil
+}
+
+func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is respectively lower, equal or greater than o.
+// Variants are not taken into account.
+func (v *Version) Compare(o *Version) int {
+	for _, c := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if c[0] != c[1] {
+			return compareInts(c[0], c[1])
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	default:
+		return compareNatural(strings.ToLower(v.Prerelease), strings.ToLower(o.Prerelease))
+	}
+}
+
+// LessThan returns true if v is lower than o
+func (v *Version) LessThan(o *Version) bool {
+	return v.Compare(o) < 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareNatural compares strings with their numeric parts compared as numbers, so that rc2 < rc10
+func compareNatural(a, b string) int {
+	pa := numberRegexp.FindAllString(a, -1)
+	pb := numberRegexp.FindAllString(b, -1)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, errA := strconv.Atoi(pa[i])
+		nb, errB := strconv.Atoi(pb[i])
+		if errA == nil && errB == nil {
+			if na != nb {
+				return compareInts(na, nb)
+			}
+			continue
+		}
+		if c := strings.Compare(pa[i], pb[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(pa), len(pb))
+}
@@ -0,0 +1,100 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package semver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		tag      string
+		expected Version
+	}{
+		{tag: "1.4.2", expected: Version{Major: 1, Minor: 4, Patch: 2}},
+		{tag: "v1.4", expected: Version{Major: 1, Minor: 4}},
+		{tag: "3", expected: Version{Major: 3}},
+		{tag: "1.4.2-alpine", expected: Version{Major: 1, Minor: 4, Patch: 2, Variant: "alpine"}},
+		{tag: "3.12-alpine3.19", expected: Version{Major: 3, Minor: 12, Variant: "alpine3.19"}},
+		{tag: "2.0.0-rc1", expected: Version{Major: 2, Prerelease: "rc1"}},
+		{tag: "2.0.0-beta.2-slim", expected: Version{Major: 2, Prerelease: "beta.2", Variant: "slim"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.tag, func(t *testing.T) {
+			v, err := Parse(testCase.tag)
+			assert.NilError(t, err)
+			testCase.expected.Original = testCase.tag
+			assert.Equal(t, *v, testCase.expected)
+		})
+	}
+	for _, tag := range []string{"latest", "alpine", "1.4.2.1", "sha-abc123"} {
+		_, err := Parse(tag)
+		assert.Error(t, err, `"`+tag+`" is not a semantic version`)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"0.9", "1.0.0-alpha", "1.0.0-rc2", "1.0.0-rc10", "1.0", "1.0.1", "v1.2", "1.10.0", "2"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := Parse(ordered[i])
+		assert.NilError(t, err)
+		b, err := Parse(ordered[i+1])
+		assert.NilError(t, err)
+		assert.Assert(t, a.LessThan(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Assert(t, !b.LessThan(a), "%s > %s", ordered[i+1], ordered[i])
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		matching   []string
+		others     []string
+	}{
+		{constraint: "^1.4", matching: []string{"1.4.0", "1.4.9", "1.9"}, others: []string{"1.3.9", "2.0.0", "2.0.0-rc1"}},
+		{constraint: "^0.4.1", matching: []string{"0.4.1", "0.4.5"}, others: []string{"0.5.0", "0.4.0"}},
+		{constraint: "~1.4.2", matching: []string{"1.4.2", "1.4.10"}, others: []string{"1.5.0", "1.4.1"}},
+		{constraint: "1.4.x", matching: []string{"1.4.0", "1.4.3"}, others: []string{"1.5.0"}},
+		{constraint: "1.4", matching: []string{"1.4.0", "1.4.3"}, others: []string{"1.5.0"}},
+		{constraint: "=1.4.2", matching: []string{"1.4.2", "v1.4.2-alpine"}, others: []string{"1.4.3"}},
+		{constraint: ">=1.2, <2", matching: []string{"1.2.0", "1.99"}, others: []string{"1.1", "2.0.0"}},
+		{constraint: "~x", matching: []string{"0.1", "1.4.2", "10.0.0"}},
+		{constraint: "~*", matching: []string{"0.1", "1.4.2", "10.0.0"}},
+		{constraint: "^*", matching: []string{"0.1", "1.4.2", "10.0.0"}},
+		{constraint: "1.x || >=3.1", matching: []string{"1.2", "3.1", "4.0"}, others: []string{"2.5", "3.0"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(testCase.constraint)
+			assert.NilError(t, err)
+			for _, tag := range testCase.matching {
+				v, err := Parse(tag)
+				assert.NilError(t, err)
+				assert.Assert(t, c.Check(v), "%s should match %s", tag, testCase.constraint)
+			}
+			for _, tag := range testCase.others {
+				v, err := Parse(tag)
+				assert.NilError(t, err)
+				assert.Assert(t, !c.Check(v), "%s should not match %s", tag, testCase.constraint)
+			}
+		})
+	}
+	_, err := ParseConstraint("^latest")
+	assert.Error(t, err, `invalid constraint "^latest": "^latest" is not a valid range`)
+}
@@ -29,6 +29,7 @@ import (
 	cliflags "github.com/docker/cli/cli/flags"
 
 	"github.com/docker/hub-tool/internal/commands"
+	"github.com/docker/hub-tool/internal/errdef"
 	"github.com/docker/hub-tool/pkg/credentials"
 	"github.com/docker/hub-tool/pkg/hub"
 )
@@ -70,7 +71,9 @@ func main() {
 
 	rootCmd := commands.NewRootCmd(dockerCli, hubClient, store, os.Args[0])
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
-		os.Exit(1)
+		errorFormat, _ := rootCmd.PersistentFlags().GetString("error-format")
+		_ = errdef.Print(dockerCli.Err(), err, errorFormat)
+		os.Exit(errdef.ExitCode(err))
 	}
 	os.Exit(0)
 }
@@ -0,0 +1,148 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// AuditLogsURL path to the Hub API listing the audit logs of an account
+	AuditLogsURL = "/v2/auditlogs/%s"
+)
+
+// AuditLog is an event of the audit log of an organization, like the deletion of a repository
+type AuditLog struct {
+	Account     string
+	Action      string
+	Name        string
+	Actor       string
+	Description string
+	Data        map[string]string
+	Timestamp   time.Time
+}
+
+// WithAuditLogsFrom adds a from query parameter to the request, Hub only returning the events since this time
+func WithAuditLogsFrom(from time.Time) RequestOp {
+	return func(req *http.Request) error {
+		values, err := url.ParseQuery(req.URL.RawQuery)
+		if err != nil {
+			return err
+		}
+		values.Set("from", from.UTC().Format(time.RFC3339))
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// WithAuditLogsAction adds an action query parameter to the request, Hub only returning the events of this action
+func WithAuditLogsAction(action string) RequestOp {
+	return func(req *http.Request) error {
+		values, err := url.ParseQuery(req.URL.RawQuery)
+		if err != nil {
+			return err
+		}
+		values.Set("action", action)
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
+// GetAuditLogs returns the audit logs of an account, the most recent events first
+func (c *Client) GetAuditLogs(account string, reqOps ...RequestOp) ([]AuditLog, int, error) {
+	u, err := url.Parse(c.domain + fmt.Sprintf(AuditLogsURL, account))
+	if err != nil {
+		return nil, 0, err
+	}
+	q := url.Values{}
+	q.Add("page_size", fmt.Sprintf("%v", itemsPerPage))
+	q.Add("page", "1")
+	u.RawQuery = q.Encode()
+
+	logs, total, next, err := c.getAuditLogsPage(u.String(), reqOps...)
+	if err != nil {
+		return nil, 0, err
+	}
+	stop, err := c.handlePage(logs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if c.fetchAllElements {
+		for next != "" && !stop {
+			pageLogs, _, n, err := c.getAuditLogsPage(next, reqOps...)
+			if err != nil {
+				return nil, 0, err
+			}
+			if stop, err = c.handlePage(pageLogs); err != nil {
+				return nil, 0, err
+			}
+			next = n
+			logs = append(logs, pageLogs...)
+		}
+	}
+
+	return logs, total, nil
+}
+
+func (c *Client) getAuditLogsPage(url string, reqOps ...RequestOp) ([]AuditLog, int, string, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	response, err := c.doRequest(req, append(reqOps, withHubToken(c.token))...)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	var hubResponse hubAuditLogResponse
+	if err := json.Unmarshal(response, &hubResponse); err != nil {
+		return nil, 0, "", err
+	}
+	var logs []AuditLog
+	for _, result := range hubResponse.Logs {
+		logs = append(logs, AuditLog{
+			Account:     result.Account,
+			Action:      result.Action,
+			Name:        result.Name,
+			Actor:       result.Actor,
+			Description: result.ActionDescription,
+			Data:        result.Data,
+			Timestamp:   result.Timestamp,
+		})
+	}
+	return logs, hubResponse.Count, hubResponse.Next, nil
+}
+
+type hubAuditLogResponse struct {
+	Count    int                 `json:"count"`
+	Next     string              `json:"next,omitempty"`
+	Previous string              `json:"previous,omitempty"`
+	Logs     []hubAuditLogResult `json:"logs,omitempty"`
+}
+
+type hubAuditLogResult struct {
+	Account           string            `json:"account"`
+	Action            string            `json:"action"`
+	Name              string            `json:"name"`
+	Actor             string            `json:"actor"`
+	Data              map[string]string `json:"data"`
+	Timestamp         time.Time         `json:"timestamp"`
+	ActionDescription string            `json:"action_description"`
+}
@@ -0,0 +1,56 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGetAuditLogs(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v2/auditlogs/myorg")
+		assert.Equal(t, r.URL.Query().Get("action"), "repo.delete")
+		assert.Equal(t, r.URL.Query().Get("from"), "2021-01-02T03:04:05Z")
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprintf(w, `{"count": 2, "next": "%s/v2/auditlogs/myorg?page=2&page_size=100", "logs": [
+				{"account": "myorg", "action": "repo.delete", "name": "myorg/app", "actor": "alice", "data": {"digest": "sha256:abc"}, "timestamp": "2021-01-03T00:00:00Z", "action_description": "deleted repository app"}
+			]}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"count": 2, "logs": [{"account": "myorg", "action": "repo.delete", "name": "myorg/tools", "actor": "bob", "timestamp": "2021-01-02T12:00:00Z"}]}`)
+	}))
+	defer server.Close()
+	client, err := NewClient(WithAllElements())
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	logs, total, err := client.GetAuditLogs("myorg", WithAuditLogsAction("repo.delete"), WithAuditLogsFrom(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.NilError(t, err)
+	assert.Equal(t, total, 2)
+	assert.Equal(t, len(logs), 2)
+	assert.Equal(t, logs[0].Actor, "alice")
+	assert.Equal(t, logs[0].Description, "deleted repository app")
+	assert.DeepEqual(t, logs[0].Data, map[string]string{"digest": "sha256:abc"})
+	assert.Equal(t, logs[1].Name, "myorg/tools")
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -43,6 +44,10 @@ const (
 	itemsPerPage = 100
 )
 
+// ErrStopPaging is returned by a page handler to stop fetching the next pages, the elements
+// of the pages already fetched are then returned
+var ErrStopPaging = errors.New("stop paging")
+
 // Client sends authenticated calls to the Hub API
 type Client struct {
 	AuthConfig registry.AuthConfig
@@ -55,6 +60,7 @@ type Client struct {
 	password         string
 	account          string
 	fetchAllElements bool
+	pageHandler      func(interface{}) error
 	in               io.Reader
 	out              io.Writer
 }
@@ -117,6 +123,16 @@ func WithAllElements() ClientOp {
 	}
 }
 
+// WithPageHandler calls the handler with each page of elements as soon as it is fetched,
+// letting the caller print them without waiting for all the pages. The handler returns
+// ErrStopPaging to stop fetching the next pages.
+func WithPageHandler(handler func(page interface{}) error) ClientOp {
+	return func(c *Client) error {
+		c.pageHandler = handler
+		return nil
+	}
+}
+
 // WithContext set the client context
 func WithContext(ctx context.Context) ClientOp {
 	return func(c *Client) error {
@@ -182,6 +198,20 @@ func WithHTTPClient(client *http.Client) ClientOp {
 	}
 }
 
+// handlePage calls the page handler, returning whether the next pages shouldn't be fetched
+func (c *Client) handlePage(page interface{}) (bool, error) {
+	if c.pageHandler == nil {
+		return false, nil
+	}
+	if err := c.pageHandler(page); err != nil {
+		if errors.Is(err, ErrStopPaging) {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
+
 func withHubToken(token string) RequestOp {
 	return func(req *http.Request) error {
 		req.Header["Authorization"] = []string{fmt.Sprintf("Bearer %s", token)}
@@ -202,6 +232,19 @@ func WithSortingOrder(order string) RequestOp {
 	}
 }
 
+// WithNameFilter adds a name query parameter to the request, Hub only returning the elements whose name contains it
+func WithNameFilter(name string) RequestOp {
+	return func(req *http.Request) error {
+		values, err := url.ParseQuery(req.URL.RawQuery)
+		if err != nil {
+			return err
+		}
+		values.Set("name", name)
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+
 // Login tries to authenticate, it will call the twoFactorCodeProvider if the
 // user has 2FA activated
 func (c *Client) Login(username string, password string, twoFactorCodeProvider func() (string, error)) (string, string, error) {
@@ -316,15 +359,21 @@ func (c *Client) doRequest(req *http.Request, reqOps ...RequestOp) ([]byte, erro
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if resp.StatusCode == http.StatusForbidden {
-			return nil, &forbiddenError{}
-		}
 		buf, err := io.ReadAll(resp.Body)
 		log.Debugf("bad status code %q: %s", resp.Status, buf)
-		if err == nil {
-			if ok, err := extractError(buf, resp); ok {
-				return nil, err
-			}
+		if err != nil {
+			buf = nil
+		}
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, &authenticationError{detail: errorDetail(buf)}
+		case http.StatusForbidden:
+			return nil, &forbiddenError{detail: errorDetail(buf)}
+		case http.StatusTooManyRequests:
+			return nil, &tooManyRequestsError{detail: errorDetail(buf)}
+		}
+		if ok, err := extractError(buf, resp); ok {
+			return nil, err
 		}
 		return nil, fmt.Errorf("bad status code %q", resp.Status)
 	}
@@ -356,13 +405,21 @@ func (c *Client) doRawRequest(req *http.Request, reqOps ...RequestOp) (*http.Res
 }
 
 func extractError(buf []byte, resp *http.Response) (bool, error) {
+	if msg := errorDetail(buf); msg != "" {
+		return true, fmt.Errorf("failed to authenticate: bad status code %q: %s", resp.Status, msg)
+	}
+	return false, nil
+}
+
+// errorDetail returns the message of an error response from Hub, or an empty string
+func errorDetail(buf []byte) string {
 	var responseBody map[string]string
 	if err := json.Unmarshal(buf, &responseBody); err == nil {
 		for _, k := range []string{"message", "detail"} {
 			if msg, ok := responseBody[k]; ok {
-				return true, fmt.Errorf("failed to authenticate: bad status code %q: %s", resp.Status, msg)
+				return msg
 			}
 		}
 	}
-	return false, nil
+	return ""
 }
@@ -17,6 +17,7 @@
 package hub
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -39,3 +40,41 @@ func TestDoRequestAddsCustomUserAgent(t *testing.T) {
 	_, err = client.doRequest(req)
 	assert.NilError(t, err)
 }
+
+func TestDoRequestKeepsTheErrorDetail(t *testing.T) {
+	testCases := []struct {
+		status   int
+		body     string
+		is       func(error) bool
+		expected string
+	}{
+		{http.StatusUnauthorized, `{"detail": "Token expired"}`, IsAuthenticationError, "authentication error: Token expired"},
+		{http.StatusUnauthorized, "", IsAuthenticationError, "authentication error"},
+		{http.StatusForbidden, `{"message": "not an owner"}`, IsForbiddenError, "operation not permitted: not an owner"},
+		{http.StatusTooManyRequests, `{"detail": "retry in 60s"}`, IsTooManyRequestsError, "too many requests, rate limit exceeded: retry in 60s"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+			req, err := http.NewRequest("GET", server.URL, nil)
+			assert.NilError(t, err)
+			client, err := NewClient()
+			assert.NilError(t, err)
+			_, err = client.doRequest(req)
+			assert.Assert(t, tc.is(err))
+			assert.Error(t, err, tc.expected)
+		})
+	}
+}
+
+func TestRegistryServer(t *testing.T) {
+	assert.Equal(t, RegistryServer(), "https://index.docker.io/v1/")
+
+	t.Setenv("DOCKER_HUB_API_URL", "https://hub.example.com")
+	t.Setenv("DOCKER_REGISTRY_URL", "registry.example.com")
+	assert.Equal(t, RegistryServer(), "registry.example.com")
+}
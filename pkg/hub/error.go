@@ -16,19 +16,27 @@
 
 package hub
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type authenticationError struct {
+	// detail is the message given by Hub, if any
+	detail string
 }
 
 func (a authenticationError) Error() string {
+	if a.detail != "" {
+		return "authentication error: " + a.detail
+	}
 	return "authentication error"
 }
 
 // IsAuthenticationError check if the error type is an authentication error
 func IsAuthenticationError(err error) bool {
-	_, ok := err.(*authenticationError)
-	return ok
+	var e *authenticationError
+	return errors.As(err, &e)
 }
 
 type invalidTokenError struct {
@@ -41,20 +49,26 @@ func (i invalidTokenError) Error() string {
 
 // IsInvalidTokenError check if the error type is an invalid token error
 func IsInvalidTokenError(err error) bool {
-	_, ok := err.(*invalidTokenError)
-	return ok
+	var e *invalidTokenError
+	return errors.As(err, &e)
 }
 
-type forbiddenError struct{}
+type forbiddenError struct {
+	// detail is the message given by Hub, if any
+	detail string
+}
 
 func (f forbiddenError) Error() string {
+	if f.detail != "" {
+		return "operation not permitted: " + f.detail
+	}
 	return "operation not permitted"
 }
 
 // IsForbiddenError check if the error type is a forbidden error
 func IsForbiddenError(err error) bool {
-	_, ok := err.(*forbiddenError)
-	return ok
+	var e *forbiddenError
+	return errors.As(err, &e)
 }
 
 type notFoundError struct{}
@@ -65,6 +79,24 @@ func (n notFoundError) Error() string {
 
 // IsNotFoundError check if the error type is a not found error
 func IsNotFoundError(err error) bool {
-	_, ok := err.(*notFoundError)
-	return ok
+	var e *notFoundError
+	return errors.As(err, &e)
+}
+
+type tooManyRequestsError struct {
+	// detail is the message given by Hub, if any
+	detail string
+}
+
+func (t tooManyRequestsError) Error() string {
+	if t.detail != "" {
+		return "too many requests, rate limit exceeded: " + t.detail
+	}
+	return "too many requests, rate limit exceeded"
+}
+
+// IsTooManyRequestsError check if the error type is a rate limiting error
+func IsTooManyRequestsError(err error) bool {
+	var e *tooManyRequestsError
+	return errors.As(err, &e)
 }
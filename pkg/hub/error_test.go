@@ -18,6 +18,7 @@ package hub
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -41,4 +42,10 @@ func TestIsForbiddenError(t *testing.T) {
 func TestIsNotFoundError(t *testing.T) {
 	assert.Assert(t, IsNotFoundError(&notFoundError{}))
 	assert.Assert(t, !IsNotFoundError(errors.New("")))
+	assert.Assert(t, IsNotFoundError(fmt.Errorf("wrapped: %w", &notFoundError{})))
+}
+
+func TestIsTooManyRequestsError(t *testing.T) {
+	assert.Assert(t, IsTooManyRequestsError(&tooManyRequestsError{}))
+	assert.Assert(t, !IsTooManyRequestsError(errors.New("")))
 }
@@ -20,6 +20,7 @@ import (
 	"os"
 
 	"github.com/docker/docker/api/types/registry"
+	dockerregistry "github.com/docker/docker/registry"
 )
 
 // Instance stores all the specific pieces needed to dialog with Hub
@@ -60,3 +61,13 @@ func getInstance() *Instance {
 
 	return &hub
 }
+
+// RegistryServer returns the address under which the docker CLI stores the
+// credentials of the current hub instance registry
+func RegistryServer() string {
+	instance := getInstance()
+	if instance.RegistryInfo.Official {
+		return dockerregistry.IndexServer
+	}
+	return instance.RegistryInfo.Name
+}
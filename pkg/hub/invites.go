@@ -0,0 +1,189 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// InvitesURL path to the Hub API listing the pending invites of an organization
+	InvitesURL = "/v2/orgs/%s/invites"
+	// BulkInvitesURL path to the Hub API inviting members to an organization
+	BulkInvitesURL = "/v2/invites/bulk"
+	// InviteURL path to the Hub API invite
+	InviteURL = "/v2/invites/%s"
+	// ResendInviteURL path to the Hub API sending an invite again
+	ResendInviteURL = "/v2/invites/%s/resend"
+
+	// RoleMember can pull the repositories of the teams
+	RoleMember = "member"
+	// RoleEditor can also manage the repositories
+	RoleEditor = "editor"
+	// RoleOwner administrates the organization
+	RoleOwner = "owner"
+
+	// InviteStatusInvited is the status of an invitee who was sent an invite
+	InviteStatusInvited = "invited"
+	// InviteStatusExistingMember is the status of an invitee already member of the organization
+	InviteStatusExistingMember = "existing_org_member"
+)
+
+// Roles are the roles a member can be given in an organization
+var Roles = []string{RoleMember, RoleEditor, RoleOwner}
+
+// Invite is a pending invite to join an organization, sent to a Docker ID or an email
+type Invite struct {
+	ID           string
+	Inviter      string
+	Invitee      string
+	Organization string
+	Team         string
+	Role         string
+	CreatedAt    time.Time
+}
+
+// InviteResult is the outcome of inviting a Docker ID or an email
+type InviteResult struct {
+	Invitee string
+	Status  string
+	Invite  *Invite
+}
+
+// InviteMembers invites Docker IDs or emails to join a team of the organization with a role.
+// The result of each invitee is returned in its status.
+func (c *Client) InviteMembers(organization, team, role string, invitees []string) ([]InviteResult, error) {
+	data, err := json.Marshal(hubBulkInviteRequest{
+		Organization: organization,
+		Team:         team,
+		Role:         role,
+		Invitees:     invitees,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", c.domain+BulkInvitesURL, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.doRequest(req, withHubToken(c.token))
+	if err != nil {
+		return nil, err
+	}
+	var hubResponse hubBulkInviteResponse
+	if err := json.Unmarshal(response, &hubResponse); err != nil {
+		return nil, err
+	}
+	var results []InviteResult
+	for _, invitee := range hubResponse.Invitees {
+		result := InviteResult{Invitee: invitee.Invitee, Status: invitee.Status}
+		if invitee.Invite != nil {
+			invite := convertInvite(*invitee.Invite)
+			result.Invite = &invite
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// GetInvites returns the pending invites of an organization
+func (c *Client) GetInvites(organization string) ([]Invite, error) {
+	req, err := http.NewRequest("GET", c.domain+fmt.Sprintf(InvitesURL, organization), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.doRequest(req, withHubToken(c.token))
+	if err != nil {
+		return nil, err
+	}
+	var hubResponse hubInvitesResponse
+	if err := json.Unmarshal(response, &hubResponse); err != nil {
+		return nil, err
+	}
+	var invites []Invite
+	for _, result := range hubResponse.Data {
+		invites = append(invites, convertInvite(result))
+	}
+	return invites, nil
+}
+
+// ResendInvite sends the invite again to the invitee
+func (c *Client) ResendInvite(inviteID string) error {
+	req, err := http.NewRequest("PATCH", c.domain+fmt.Sprintf(ResendInviteURL, inviteID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+// CancelInvite deletes a pending invite
+func (c *Client) CancelInvite(inviteID string) error {
+	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(InviteURL, inviteID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+type hubBulkInviteRequest struct {
+	Organization string   `json:"org"`
+	Team         string   `json:"team,omitempty"`
+	Role         string   `json:"role,omitempty"`
+	Invitees     []string `json:"invitees"`
+}
+
+type hubBulkInviteResponse struct {
+	Invitees []hubInviteeResult `json:"invitees"`
+}
+
+type hubInviteeResult struct {
+	Invitee string           `json:"invitee"`
+	Status  string           `json:"status"`
+	Invite  *hubInviteResult `json:"invite,omitempty"`
+}
+
+type hubInvitesResponse struct {
+	Data []hubInviteResult `json:"data"`
+}
+
+type hubInviteResult struct {
+	ID              string    `json:"id"`
+	InviterUsername string    `json:"inviter_username"`
+	Invitee         string    `json:"invitee"`
+	Organization    string    `json:"org"`
+	Team            string    `json:"team"`
+	Role            string    `json:"role"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func convertInvite(response hubInviteResult) Invite {
+	return Invite{
+		ID:           response.ID,
+		Inviter:      response.InviterUsername,
+		Invitee:      response.Invitee,
+		Organization: response.Organization,
+		Team:         response.Team,
+		Role:         response.Role,
+		CreatedAt:    response.CreatedAt,
+	}
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestInviteMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.URL.Path, "/v2/invites/bulk")
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"org":"myorg","team":"dev","role":"member","invitees":["alice@example.com","bob"]}`)
+		fmt.Fprint(w, `{"invitees": [
+			{"invitee": "alice@example.com", "status": "invited", "invite": {"id": "e36eca69", "inviter_username": "admin", "invitee": "alice@example.com", "org": "myorg", "team": "dev", "role": "member", "created_at": "2021-01-02T03:04:05Z"}},
+			{"invitee": "bob", "status": "existing_org_member"}
+		]}`)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	results, err := client.InviteMembers("myorg", "dev", RoleMember, []string{"alice@example.com", "bob"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(results), 2)
+	assert.Equal(t, results[0].Status, InviteStatusInvited)
+	assert.Equal(t, results[0].Invite.ID, "e36eca69")
+	assert.Equal(t, results[0].Invite.Inviter, "admin")
+	assert.Equal(t, results[1].Status, InviteStatusExistingMember)
+	assert.Assert(t, results[1].Invite == nil)
+}
+
+func TestGetInvites(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v2/orgs/myorg/invites")
+		fmt.Fprint(w, `{"data": [{"id": "e36eca69", "inviter_username": "admin", "invitee": "alice@example.com", "org": "myorg", "team": "dev", "role": "member", "created_at": "2021-01-02T03:04:05Z"}]}`)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	invites, err := client.GetInvites("myorg")
+	assert.NilError(t, err)
+	assert.Equal(t, len(invites), 1)
+	assert.Equal(t, invites[0].Invitee, "alice@example.com")
+	assert.Equal(t, invites[0].Organization, "myorg")
+	assert.Equal(t, invites[0].CreatedAt.Year(), 2021)
+}
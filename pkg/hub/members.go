@@ -29,6 +29,8 @@ const (
 	MembersURL = "/v2/orgs/%s/members/"
 	//MembersPerTeamURL path to the Hub API listing the members in a team
 	MembersPerTeamURL = "/v2/orgs/%s/groups/%s/members/"
+	//MemberURL path to the Hub API member of an organization
+	MemberURL = "/v2/orgs/%s/members/%s"
 )
 
 // Member is a user part of an organization
@@ -52,12 +54,19 @@ func (c *Client) GetMembers(organization string) ([]Member, error) {
 	if err != nil {
 		return nil, err
 	}
+	stop, err := c.handlePage(members)
+	if err != nil {
+		return nil, err
+	}
 
-	for next != "" {
+	for next != "" && !stop {
 		pageMembers, n, err := c.getMembersPage(next)
 		if err != nil {
 			return nil, err
 		}
+		if stop, err = c.handlePage(pageMembers); err != nil {
+			return nil, err
+		}
 		next = n
 		members = append(members, pageMembers...)
 	}
@@ -109,6 +118,16 @@ func (c *Client) GetMembersPerTeam(organization, team string) ([]Member, error)
 	return members, nil
 }
 
+// RemoveMember removes a member from an organization and all its teams
+func (c *Client) RemoveMember(organization, username string) error {
+	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(MemberURL, organization, username), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
 func (c *Client) getMembersPage(url string) ([]Member, string, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
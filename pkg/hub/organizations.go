@@ -59,12 +59,19 @@ func (c *Client) GetOrganizations(ctx context.Context) ([]Organization, error) {
 	if err != nil {
 		return nil, err
 	}
+	stop, err := c.handlePage(organizations)
+	if err != nil {
+		return nil, err
+	}
 
-	for next != "" {
+	for next != "" && !stop {
 		pageOrganizations, n, err := c.getOrganizationsPage(ctx, next)
 		if err != nil {
 			return nil, err
 		}
+		if stop, err = c.handlePage(pageOrganizations); err != nil {
+			return nil, err
+		}
 		next = n
 		organizations = append(organizations, pageOrganizations...)
 	}
@@ -0,0 +1,259 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// OrgTokensURL path to the Hub API listing the access tokens of an organization
+	OrgTokensURL = "/v2/orgs/%s/access-tokens"
+	// OrgTokenURL path to the Hub API organization access token
+	OrgTokenURL = "/v2/orgs/%s/access-tokens/%s"
+
+	// ResourceTypeRepository is the type of the repository resources an organization token can access
+	ResourceTypeRepository = "TYPE_REPO"
+	// ScopeRepoPull allows to pull a repository
+	ScopeRepoPull = "repo-pull"
+	// ScopeRepoPush allows to push a repository
+	ScopeRepoPush = "repo-push"
+)
+
+// OrgToken is an organization access token, not tied to the account of a member.
+// The token field will only be filled at creation and can never been accessed again.
+type OrgToken struct {
+	ID          uuid.UUID
+	Label       string
+	Description string
+	CreatedBy   string
+	IsActive    bool
+	CreatedAt   time.Time
+	LastUsed    time.Time
+	ExpiresAt   time.Time
+	Resources   []OrgTokenResource
+	Token       string
+}
+
+// OrgTokenResource is a resource an organization access token can access, like a repository
+type OrgTokenResource struct {
+	Type   string
+	Path   string
+	Scopes []string
+}
+
+// CreateOrgToken creates an organization access token and returns the token field only once.
+// A zero expiration time creates a token which never expires.
+func (c *Client) CreateOrgToken(organization, label, description string, resources []OrgTokenResource, expiresAt time.Time) (*OrgToken, error) {
+	tokenRequest := hubOrgTokenRequest{
+		Label:       label,
+		Description: description,
+		Resources:   convertOrgTokenResources(resources),
+	}
+	if !expiresAt.IsZero() {
+		tokenRequest.ExpiresAt = &expiresAt
+	}
+	data, err := json.Marshal(tokenRequest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", c.domain+fmt.Sprintf(OrgTokensURL, organization), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	return c.doOrgTokenRequest(req)
+}
+
+// GetOrgTokens returns the access tokens of an organization
+func (c *Client) GetOrgTokens(organization string) ([]OrgToken, int, error) {
+	u, err := url.Parse(c.domain + fmt.Sprintf(OrgTokensURL, organization))
+	if err != nil {
+		return nil, 0, err
+	}
+	q := url.Values{}
+	q.Add("page_size", fmt.Sprintf("%v", itemsPerPage))
+	q.Add("page", "1")
+	u.RawQuery = q.Encode()
+
+	tokens, total, next, err := c.getOrgTokensPage(u.String())
+	if err != nil {
+		return nil, 0, err
+	}
+	stop, err := c.handlePage(tokens)
+	if err != nil {
+		return nil, 0, err
+	}
+	if c.fetchAllElements {
+		for next != "" && !stop {
+			pageTokens, _, n, err := c.getOrgTokensPage(next)
+			if err != nil {
+				return nil, 0, err
+			}
+			if stop, err = c.handlePage(pageTokens); err != nil {
+				return nil, 0, err
+			}
+			next = n
+			tokens = append(tokens, pageTokens...)
+		}
+	}
+
+	return tokens, total, nil
+}
+
+// GetOrgToken returns the information on one access token of an organization
+func (c *Client) GetOrgToken(organization, tokenID string) (*OrgToken, error) {
+	req, err := http.NewRequest("GET", c.domain+fmt.Sprintf(OrgTokenURL, organization, tokenID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.doOrgTokenRequest(req)
+}
+
+// UpdateOrgToken activates or deactivates an access token of an organization
+func (c *Client) UpdateOrgToken(organization, tokenID string, isActive bool) (*OrgToken, error) {
+	data, err := json.Marshal(hubOrgTokenRequest{IsActive: &isActive})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("PATCH", c.domain+fmt.Sprintf(OrgTokenURL, organization, tokenID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	return c.doOrgTokenRequest(req)
+}
+
+// RemoveOrgToken deletes an access token of an organization
+func (c *Client) RemoveOrgToken(organization, tokenID string) error {
+	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(OrgTokenURL, organization, tokenID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+func (c *Client) doOrgTokenRequest(req *http.Request) (*OrgToken, error) {
+	response, err := c.doRequest(req, withHubToken(c.token))
+	if err != nil {
+		return nil, err
+	}
+	var tokenResponse hubOrgTokenResult
+	if err := json.Unmarshal(response, &tokenResponse); err != nil {
+		return nil, err
+	}
+	token, err := convertOrgToken(tokenResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (c *Client) getOrgTokensPage(url string) ([]OrgToken, int, string, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	response, err := c.doRequest(req, withHubToken(c.token))
+	if err != nil {
+		return nil, 0, "", err
+	}
+	var hubResponse hubOrgTokenResponse
+	if err := json.Unmarshal(response, &hubResponse); err != nil {
+		return nil, 0, "", err
+	}
+	var tokens []OrgToken
+	for _, result := range hubResponse.Results {
+		token, err := convertOrgToken(result)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, hubResponse.Count, hubResponse.Next, nil
+}
+
+type hubOrgTokenRequest struct {
+	Label       string                `json:"label,omitempty"`
+	Description string                `json:"description,omitempty"`
+	IsActive    *bool                 `json:"is_active,omitempty"`
+	Resources   []hubOrgTokenResource `json:"resources,omitempty"`
+	ExpiresAt   *time.Time            `json:"expires_at,omitempty"`
+}
+
+type hubOrgTokenResponse struct {
+	Count    int                 `json:"count"`
+	Next     string              `json:"next,omitempty"`
+	Previous string              `json:"previous,omitempty"`
+	Results  []hubOrgTokenResult `json:"results,omitempty"`
+}
+
+type hubOrgTokenResult struct {
+	ID          string                `json:"id"`
+	Label       string                `json:"label"`
+	Description string                `json:"description"`
+	CreatedBy   string                `json:"created_by"`
+	IsActive    bool                  `json:"is_active"`
+	CreatedAt   time.Time             `json:"created_at"`
+	LastUsedAt  time.Time             `json:"last_used_at,omitempty"`
+	ExpiresAt   time.Time             `json:"expires_at,omitempty"`
+	Resources   []hubOrgTokenResource `json:"resources"`
+	Token       string                `json:"token"`
+}
+
+type hubOrgTokenResource struct {
+	Type   string   `json:"type"`
+	Path   string   `json:"path"`
+	Scopes []string `json:"scopes"`
+}
+
+func convertOrgTokenResources(resources []OrgTokenResource) []hubOrgTokenResource {
+	var converted []hubOrgTokenResource
+	for _, resource := range resources {
+		converted = append(converted, hubOrgTokenResource(resource))
+	}
+	return converted
+}
+
+func convertOrgToken(response hubOrgTokenResult) (OrgToken, error) {
+	id, err := uuid.Parse(response.ID)
+	if err != nil {
+		return OrgToken{}, err
+	}
+	var resources []OrgTokenResource
+	for _, resource := range response.Resources {
+		resources = append(resources, OrgTokenResource(resource))
+	}
+	return OrgToken{
+		ID:          id,
+		Label:       response.Label,
+		Description: response.Description,
+		CreatedBy:   response.CreatedBy,
+		IsActive:    response.IsActive,
+		CreatedAt:   response.CreatedAt,
+		LastUsed:    response.LastUsedAt,
+		ExpiresAt:   response.ExpiresAt,
+		Resources:   resources,
+		Token:       response.Token,
+	}, nil
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCreateOrgToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.URL.Path, "/v2/orgs/myorg/access-tokens")
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"label":"ci","resources":[{"type":"TYPE_REPO","path":"myorg/app","scopes":["repo-pull","repo-push"]}],"expires_at":"2021-02-01T00:00:00Z"}`)
+		fmt.Fprint(w, `{
+			"id": "a7a5ef25-8889-43a0-8cc7-f2a94268e861",
+			"label": "ci",
+			"created_by": "user",
+			"is_active": true,
+			"expires_at": "2021-02-01T00:00:00Z",
+			"last_used_at": null,
+			"resources": [{"type": "TYPE_REPO", "path": "myorg/app", "scopes": ["repo-pull", "repo-push"]}],
+			"token": "dckr_oat_secret"
+		}`)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	resources := []OrgTokenResource{{Type: ResourceTypeRepository, Path: "myorg/app", Scopes: []string{ScopeRepoPull, ScopeRepoPush}}}
+	token, err := client.CreateOrgToken("myorg", "ci", "", resources, time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.NilError(t, err)
+	assert.Equal(t, token.ID.String(), "a7a5ef25-8889-43a0-8cc7-f2a94268e861")
+	assert.Equal(t, token.CreatedBy, "user")
+	assert.Assert(t, token.LastUsed.IsZero())
+	assert.DeepEqual(t, token.Resources, resources)
+	assert.Equal(t, token.Token, "dckr_oat_secret")
+}
+
+func TestUpdateOrgTokenOnlySendsActiveness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPatch)
+		assert.Equal(t, r.URL.Path, "/v2/orgs/myorg/access-tokens/a7a5ef25-8889-43a0-8cc7-f2a94268e861")
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"is_active":false}`)
+		fmt.Fprint(w, `{"id": "a7a5ef25-8889-43a0-8cc7-f2a94268e861", "is_active": false}`)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	token, err := client.UpdateOrgToken("myorg", "a7a5ef25-8889-43a0-8cc7-f2a94268e861", false)
+	assert.NilError(t, err)
+	assert.Assert(t, !token.IsActive)
+}
@@ -0,0 +1,153 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// RepositoryGroupsURL path to the Hub API listing the team permissions on a repository
+	RepositoryGroupsURL = "/v2/repositories/%s/groups/"
+	// RepositoryGroupURL path to the Hub API permission of a team on a repository
+	RepositoryGroupURL = "/v2/repositories/%s/groups/%d/"
+
+	// PermissionRead allows to view and pull a repository
+	PermissionRead = "read"
+	// PermissionWrite also allows to push a repository
+	PermissionWrite = "write"
+	// PermissionAdmin also allows to manage a repository
+	PermissionAdmin = "admin"
+)
+
+// Permissions are the permissions a team can be given on a repository
+var Permissions = []string{PermissionRead, PermissionWrite, PermissionAdmin}
+
+// TeamPermission is the permission of a team on a repository
+type TeamPermission struct {
+	TeamID     int
+	Team       string
+	Permission string
+}
+
+// GetRepositoryPermissions returns the permissions of the teams on a repository
+func (c *Client) GetRepositoryPermissions(repository string) ([]TeamPermission, error) {
+	u, err := url.Parse(c.domain + fmt.Sprintf(RepositoryGroupsURL, repository))
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	q.Add("page_size", fmt.Sprintf("%v", itemsPerPage))
+	q.Add("page", "1")
+	u.RawQuery = q.Encode()
+
+	var permissions []TeamPermission
+	next := u.String()
+	for next != "" {
+		page, n, err := c.getRepositoryPermissionsPage(next)
+		if err != nil {
+			return nil, err
+		}
+		next = n
+		permissions = append(permissions, page...)
+	}
+	return permissions, nil
+}
+
+// AddRepositoryPermission gives a permission on a repository to a team
+func (c *Client) AddRepositoryPermission(repository string, teamID int, permission string) error {
+	data, err := json.Marshal(hubRepositoryGroupRequest{GroupID: teamID, Permission: permission})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.domain+fmt.Sprintf(RepositoryGroupsURL, repository), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+// UpdateRepositoryPermission changes the permission of a team on a repository
+func (c *Client) UpdateRepositoryPermission(repository string, teamID int, permission string) error {
+	data, err := json.Marshal(hubRepositoryGroupRequest{Permission: permission})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, c.domain+fmt.Sprintf(RepositoryGroupURL, repository, teamID), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+// RemoveRepositoryPermission revokes the permission of a team on a repository
+func (c *Client) RemoveRepositoryPermission(repository string, teamID int) error {
+	req, err := http.NewRequest(http.MethodDelete, c.domain+fmt.Sprintf(RepositoryGroupURL, repository, teamID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+func (c *Client) getRepositoryPermissionsPage(url string) ([]TeamPermission, string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	response, err := c.doRequest(req, withHubToken(c.token))
+	if err != nil {
+		return nil, "", err
+	}
+	var hubResponse hubRepositoryGroupResponse
+	if err := json.Unmarshal(response, &hubResponse); err != nil {
+		return nil, "", err
+	}
+	var permissions []TeamPermission
+	for _, result := range hubResponse.Results {
+		permissions = append(permissions, TeamPermission{
+			TeamID:     result.GroupID,
+			Team:       result.GroupName,
+			Permission: result.Permission,
+		})
+	}
+	return permissions, hubResponse.Next, nil
+}
+
+type hubRepositoryGroupRequest struct {
+	GroupID    int    `json:"group_id,omitempty"`
+	Permission string `json:"permission"`
+}
+
+type hubRepositoryGroupResponse struct {
+	Count    int                        `json:"count"`
+	Next     string                     `json:"next,omitempty"`
+	Previous string                     `json:"previous,omitempty"`
+	Results  []hubRepositoryGroupResult `json:"results,omitempty"`
+}
+
+type hubRepositoryGroupResult struct {
+	GroupID    int    `json:"group_id"`
+	GroupName  string `json:"group_name"`
+	Permission string `json:"permission"`
+}
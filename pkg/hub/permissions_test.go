@@ -0,0 +1,49 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGetRepositoryPermissionsFollowsPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v2/repositories/myorg/app/groups/")
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprintf(w, `{"count": 2, "next": "%s/v2/repositories/myorg/app/groups/?page=2", "results": [{"group_id": 2, "group_name": "developers", "permission": "write"}]}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"count": 2, "results": [{"group_id": 3, "group_name": "qa", "permission": "read"}]}`)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	permissions, err := client.GetRepositoryPermissions("myorg/app")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, permissions, []TeamPermission{
+		{TeamID: 2, Team: "developers", Permission: PermissionWrite},
+		{TeamID: 3, Team: "qa", Permission: PermissionRead},
+	})
+}
@@ -17,6 +17,7 @@
 package hub
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -27,11 +28,15 @@ import (
 const (
 	// RepositoriesURL is the Hub API base URL
 	RepositoriesURL = "/v2/repositories/"
+	// RepositoryPrivacyURL is the Hub API path changing the visibility of a repository
+	RepositoryPrivacyURL = "/v2/repositories/%s/privacy/"
 )
 
 // Repository represents a Docker Hub repository
 type Repository struct {
 	Name        string
+	Namespace   string
+	Type        RepositoryType
 	Description string
 	LastUpdated time.Time
 	PullCount   int
@@ -59,13 +64,20 @@ func (c *Client) GetRepositories(account string) ([]Repository, int, error) {
 	if err != nil {
 		return nil, 0, err
 	}
+	stop, err := c.handlePage(repos)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if c.fetchAllElements {
-		for next != "" {
+		for next != "" && !stop {
 			pageRepos, _, n, err := c.getRepositoriesPage(next, account)
 			if err != nil {
 				return nil, 0, err
 			}
+			if stop, err = c.handlePage(pageRepos); err != nil {
+				return nil, 0, err
+			}
 			next = n
 			repos = append(repos, pageRepos...)
 		}
@@ -89,6 +101,35 @@ func (c *Client) RemoveRepository(repository string) error {
 	return nil
 }
 
+// UpdateRepository changes the description of a repository
+func (c *Client) UpdateRepository(repository, description string) error {
+	data, err := json.Marshal(hubRepositoryRequest{Description: &description})
+	if err != nil {
+		return err
+	}
+	repositoryURL := fmt.Sprintf("%s%s%s/", c.domain, RepositoriesURL, repository)
+	req, err := http.NewRequest(http.MethodPatch, repositoryURL, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+// SetRepositoryPrivacy makes a repository private or public
+func (c *Client) SetRepositoryPrivacy(repository string, private bool) error {
+	data, err := json.Marshal(hubRepositoryRequest{IsPrivate: &private})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.domain+fmt.Sprintf(RepositoryPrivacyURL, repository), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
 func (c *Client) getRepositoriesPage(url, account string) ([]Repository, int, string, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -106,6 +147,8 @@ func (c *Client) getRepositoriesPage(url, account string) ([]Repository, int, st
 	for _, result := range hubResponse.Results {
 		repo := Repository{
 			Name:        fmt.Sprintf("%s/%s", account, result.Name),
+			Namespace:   result.Namespace,
+			Type:        result.RepositoryType,
 			Description: result.Description,
 			LastUpdated: result.LastUpdated,
 			PullCount:   result.PullCount,
@@ -117,6 +160,11 @@ func (c *Client) getRepositoriesPage(url, account string) ([]Repository, int, st
 	return repos, hubResponse.Count, hubResponse.Next, nil
 }
 
+type hubRepositoryRequest struct {
+	Description *string `json:"description,omitempty"`
+	IsPrivate   *bool   `json:"is_private,omitempty"`
+}
+
 type hubRepositoryResponse struct {
 	Count    int                   `json:"count"`
 	Next     string                `json:"next,omitempty"`
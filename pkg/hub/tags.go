@@ -36,6 +36,7 @@ const (
 // Tag can point to a manifest or manifest list
 type Tag struct {
 	Name                string
+	Digest              string
 	FullSize            int
 	LastUpdated         time.Time
 	LastUpdaterUserName string
@@ -76,12 +77,19 @@ func (c *Client) GetTags(repository string, reqOps ...RequestOp) ([]Tag, int, er
 	if err != nil {
 		return nil, 0, err
 	}
+	stop, err := c.handlePage(tags)
+	if err != nil {
+		return nil, 0, err
+	}
 	if c.fetchAllElements {
-		for next != "" {
+		for next != "" && !stop {
 			pageTags, _, n, err := c.getTagsPage(next, repository, reqOps...)
 			if err != nil {
 				return nil, 0, err
 			}
+			if stop, err = c.handlePage(pageTags); err != nil {
+				return nil, 0, err
+			}
 			next = n
 			tags = append(tags, pageTags...)
 		}
@@ -92,7 +100,11 @@ func (c *Client) GetTags(repository string, reqOps ...RequestOp) ([]Tag, int, er
 
 // RemoveTag removes a tag in a repository on Hub
 func (c *Client) RemoveTag(repository, tag string) error {
-	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(DeleteTagURL, repository, tag), nil)
+	repoPath, err := getRepoPath(repository)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(DeleteTagURL, repoPath, tag), nil)
 	if err != nil {
 		return err
 	}
@@ -117,6 +129,7 @@ func (c *Client) getTagsPage(url, repository string, reqOps ...RequestOp) ([]Tag
 	for _, result := range hubResponse.Results {
 		tag := Tag{
 			Name:                fmt.Sprintf("%s:%s", repository, result.Name),
+			Digest:              result.Digest,
 			FullSize:            result.FullSize,
 			LastUpdated:         result.LastUpdated,
 			LastUpdaterUserName: result.LastUpdaterUserName,
@@ -142,6 +155,7 @@ type hubTagResult struct {
 	ID                  int           `json:"id"`
 	Name                string        `json:"name"`
 	ImageID             string        `json:"image_id,omitempty"`
+	Digest              string        `json:"digest,omitempty"`
 	LastUpdated         time.Time     `json:"last_updated"`
 	LastUpdater         int           `json:"last_updater"`
 	LastUpdaterUserName string        `json:"last_updater_username"`
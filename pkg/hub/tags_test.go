@@ -0,0 +1,86 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRemoveTagNormalizesRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodDelete)
+		assert.Equal(t, r.URL.Path, "/v2/repositories/library/ubuntu/tags/foo/")
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+	assert.NilError(t, client.RemoveTag("ubuntu", "foo"))
+}
+
+func TestGetTagsCallsPageHandler(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprintf(w, `{"count": 3, "next": "%s/v2/repositories/user/repo/tags/?page=2", "results": [{"name": "a"}, {"name": "b"}]}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"count": 3, "results": [{"name": "c"}]}`)
+	}))
+	defer server.Close()
+
+	var pages [][]string
+	client, err := NewClient(WithAllElements(), WithPageHandler(func(page interface{}) error {
+		var names []string
+		for _, tag := range page.([]Tag) {
+			names = append(names, tag.Name)
+		}
+		pages = append(pages, names)
+		return nil
+	}))
+	assert.NilError(t, err)
+	client.domain = server.URL
+	tags, total, err := client.GetTags("user/repo")
+	assert.NilError(t, err)
+	assert.Equal(t, total, 3)
+	assert.Equal(t, len(tags), 3)
+	assert.DeepEqual(t, pages, [][]string{{"user/repo:a", "user/repo:b"}, {"user/repo:c"}})
+}
+
+func TestGetTagsStopsPaging(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Query().Get("page"), "1", "the second page shouldn't be fetched")
+		fmt.Fprintf(w, `{"count": 3, "next": "%s/v2/repositories/user/repo/tags/?page=2", "results": [{"name": "a"}, {"name": "b"}]}`, server.URL)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAllElements(), WithPageHandler(func(page interface{}) error {
+		return ErrStopPaging
+	}))
+	assert.NilError(t, err)
+	client.domain = server.URL
+	tags, total, err := client.GetTags("user/repo")
+	assert.NilError(t, err)
+	assert.Equal(t, total, 3)
+	assert.Equal(t, len(tags), 2)
+}
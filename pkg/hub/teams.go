@@ -17,6 +17,7 @@
 package hub
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -30,10 +31,15 @@ import (
 const (
 	//GroupsURL path to the Hub API listing the groups in an organization
 	GroupsURL = "/v2/orgs/%s/groups/"
+	//GroupURL path to the Hub API group in an organization
+	GroupURL = "/v2/orgs/%s/groups/%s/"
+	//GroupMemberURL path to the Hub API member of a group
+	GroupMemberURL = "/v2/orgs/%s/groups/%s/members/%s/"
 )
 
 // Team represents a hub group in an organization
 type Team struct {
+	ID          int
 	Name        string
 	Description string
 	Members     []Member
@@ -54,12 +60,19 @@ func (c *Client) GetTeams(organization string) ([]Team, error) {
 	if err != nil {
 		return nil, err
 	}
+	stop, err := c.handlePage(teams)
+	if err != nil {
+		return nil, err
+	}
 
-	for next != "" {
+	for next != "" && !stop {
 		pageTeams, n, err := c.getTeamsPage(next, organization)
 		if err != nil {
 			return nil, err
 		}
+		if stop, err = c.handlePage(pageTeams); err != nil {
+			return nil, err
+		}
 		next = n
 		teams = append(teams, pageTeams...)
 	}
@@ -93,6 +106,82 @@ func (c *Client) GetTeamsCount(organization string) (int, error) {
 	return hubResponse.Count, nil
 }
 
+// CreateTeam creates a team in an organization
+func (c *Client) CreateTeam(organization, name, description string) (*Team, error) {
+	data, err := json.Marshal(hubGroupRequest{Name: &name, Description: &description})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", c.domain+fmt.Sprintf(GroupsURL, organization), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	return c.doTeamRequest(req)
+}
+
+// UpdateTeam renames a team or changes its description, the nil values are left unchanged
+func (c *Client) UpdateTeam(organization, team string, name, description *string) (*Team, error) {
+	data, err := json.Marshal(hubGroupRequest{Name: name, Description: description})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("PATCH", c.domain+fmt.Sprintf(GroupURL, organization, team), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	return c.doTeamRequest(req)
+}
+
+// RemoveTeam deletes a team of an organization, its members stay in the organization
+func (c *Client) RemoveTeam(organization, team string) error {
+	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(GroupURL, organization, team), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+// AddTeamMember adds a member of the organization to a team
+func (c *Client) AddTeamMember(organization, team, username string) error {
+	data, err := json.Marshal(hubGroupMemberRequest{Member: username})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.domain+fmt.Sprintf(MembersPerTeamURL, organization, team), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+// RemoveTeamMember removes a member from a team, the member stays in the organization
+func (c *Client) RemoveTeamMember(organization, team, username string) error {
+	req, err := http.NewRequest("DELETE", c.domain+fmt.Sprintf(GroupMemberURL, organization, team, username), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req, withHubToken(c.token))
+	return err
+}
+
+func (c *Client) doTeamRequest(req *http.Request) (*Team, error) {
+	response, err := c.doRequest(req, withHubToken(c.token))
+	if err != nil {
+		return nil, err
+	}
+	var result hubGroupResult
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, err
+	}
+	return &Team{
+		ID:          result.ID,
+		Name:        result.Name,
+		Description: result.Description,
+	}, nil
+}
+
 func (c *Client) getTeamsPage(url, organization string) ([]Team, string, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -116,6 +205,7 @@ func (c *Client) getTeamsPage(url, organization string) ([]Team, string, error)
 				return err
 			}
 			team := Team{
+				ID:          result.ID,
 				Name:        result.Name,
 				Description: result.Description,
 				Members:     members,
@@ -148,3 +238,12 @@ type hubGroupResult struct {
 	Description string `json:"description"`
 	ID          int    `json:"id"`
 }
+
+type hubGroupRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type hubGroupMemberRequest struct {
+	Member string `json:"member"`
+}
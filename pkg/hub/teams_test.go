@@ -0,0 +1,68 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestUpdateTeamOnlySendsChangedValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPatch)
+		assert.Equal(t, r.URL.Path, "/v2/orgs/myorg/groups/dev/")
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"description":""}`)
+		fmt.Fprint(w, `{"id": 42, "name": "dev", "description": ""}`)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	description := ""
+	team, err := client.UpdateTeam("myorg", "dev", nil, &description)
+	assert.NilError(t, err)
+	assert.Equal(t, team.Name, "dev")
+}
+
+func TestTeamMembership(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	assert.NilError(t, client.AddTeamMember("myorg", "dev", "alice"))
+	assert.NilError(t, client.RemoveTeamMember("myorg", "dev", "bob"))
+	assert.DeepEqual(t, calls, []string{
+		`POST /v2/orgs/myorg/groups/dev/members/ {"member":"alice"}`,
+		"DELETE /v2/orgs/myorg/groups/dev/members/bob/ ",
+	})
+}
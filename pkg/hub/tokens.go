@@ -32,8 +32,18 @@ const (
 	TokensURL = "/v2/api_tokens"
 	// TokenURL path to the Hub API Personal Access Token
 	TokenURL = "/v2/api_tokens/%s"
+
+	// ScopeRepoRead allows to pull the repositories
+	ScopeRepoRead = "repo:read"
+	// ScopeRepoWrite allows to pull and push the repositories
+	ScopeRepoWrite = "repo:write"
+	// ScopeRepoAdmin allows to pull, push and delete the repositories
+	ScopeRepoAdmin = "repo:admin"
 )
 
+// Scopes are the permissions which can be given to a personal access token
+var Scopes = []string{ScopeRepoRead, ScopeRepoWrite, ScopeRepoAdmin}
+
 // Token is a personal access token. The token field will only be filled at creation and can never been accessed again.
 type Token struct {
 	UUID        uuid.UUID
@@ -46,11 +56,24 @@ type Token struct {
 	IsActive    bool
 	Token       string
 	Description string
+	Scopes      []string
+	ExpiresAt   time.Time
 }
 
 // CreateToken creates a Personal Access Token and returns the token field only once
 func (c *Client) CreateToken(description string) (*Token, error) {
-	data, err := json.Marshal(hubTokenRequest{Description: description})
+	return c.CreateTokenWithOptions(description, nil, time.Time{})
+}
+
+// CreateTokenWithOptions creates a Personal Access Token restricted to the given scopes
+// and returns the token field only once. Hub gives all the scopes to a token created
+// without scopes, and a zero expiration time creates a token which never expires.
+func (c *Client) CreateTokenWithOptions(description string, scopes []string, expiresAt time.Time) (*Token, error) {
+	tokenRequest := hubTokenRequest{Description: description, Scopes: scopes}
+	if !expiresAt.IsZero() {
+		tokenRequest.ExpiresAt = &expiresAt
+	}
+	data, err := json.Marshal(tokenRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -89,12 +112,19 @@ func (c *Client) GetTokens() ([]Token, int, error) {
 	if err != nil {
 		return nil, 0, err
 	}
+	stop, err := c.handlePage(tokens)
+	if err != nil {
+		return nil, 0, err
+	}
 	if c.fetchAllElements {
-		for next != "" {
+		for next != "" && !stop {
 			pageTokens, _, n, err := c.getTokensPage(next)
 			if err != nil {
 				return nil, 0, err
 			}
+			if stop, err = c.handlePage(pageTokens); err != nil {
+				return nil, 0, err
+			}
 			next = n
 			tokens = append(tokens, pageTokens...)
 		}
@@ -190,8 +220,10 @@ func (c *Client) getTokensPage(url string) ([]Token, int, string, error) {
 }
 
 type hubTokenRequest struct {
-	Description string `json:"token_label,omitempty"`
-	IsActive    bool   `json:"is_active"`
+	Description string     `json:"token_label,omitempty"`
+	IsActive    bool       `json:"is_active"`
+	Scopes      []string   `json:"scopes,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
 }
 
 type hubTokenResponse struct {
@@ -212,6 +244,8 @@ type hubTokenResult struct {
 	IsActive    bool      `json:"is_active"`
 	Token       string    `json:"token"`
 	TokenLabel  string    `json:"token_label"`
+	Scopes      []string  `json:"scopes"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
 }
 
 func convertToken(response hubTokenResult) (Token, error) {
@@ -230,5 +264,7 @@ func convertToken(response hubTokenResult) (Token, error) {
 		IsActive:    response.IsActive,
 		Token:       response.Token,
 		Description: response.TokenLabel,
+		Scopes:      response.Scopes,
+		ExpiresAt:   response.ExpiresAt,
 	}, nil
 }
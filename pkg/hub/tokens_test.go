@@ -0,0 +1,74 @@
+/*
+   Copyright 2020 Docker Hub Tool authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+const tokenResult = `{
+	"uuid": "2ba7ea3c-7a3f-4a2d-8f3e-5d7c2c3c4b1a",
+	"token_label": "ci",
+	"is_active": true,
+	"scopes": ["repo:read"],
+	"expires_at": "2021-02-01T03:04:05Z",
+	"token": "dckr_pat_secret"
+}`
+
+func TestCreateTokenSendsScopesAndExpiration(t *testing.T) {
+	expiresAt := time.Date(2021, 2, 1, 3, 4, 5, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"token_label":"ci","is_active":false,"scopes":["repo:read"],"expires_at":"2021-02-01T03:04:05Z"}`)
+		fmt.Fprint(w, tokenResult)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	token, err := client.CreateTokenWithOptions("ci", []string{ScopeRepoRead}, expiresAt)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, token.Scopes, []string{ScopeRepoRead})
+	assert.Equal(t, token.ExpiresAt, expiresAt)
+	assert.Equal(t, token.Token, "dckr_pat_secret")
+}
+
+func TestCreateTokenWithoutScopesNorExpiration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"token_label":"ci","is_active":false}`)
+		fmt.Fprint(w, tokenResult)
+	}))
+	defer server.Close()
+	client, err := NewClient()
+	assert.NilError(t, err)
+	client.domain = server.URL
+
+	_, err = client.CreateToken("ci")
+	assert.NilError(t, err)
+}